@@ -0,0 +1,280 @@
+package ewhs
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// streetNumberLast matches "Nijverheidsweg 27a", "Rue de la Loi 16 bus 3"
+	// and "Straße des 17. Juni 135".
+	streetNumberLast = regexp.MustCompile(`^(.*?\p{L}.*?)[\s,]+(\d+)(?:` +
+		`\s*[-/]\s*(\d{1,4}[a-zA-Z]?)|` +
+		`\s*[-/]?\s*(` +
+		`(?i:bus|boite|boîte|bte|box)\s*\d+[a-zA-Z]?|` +
+		`(?i:bis|ter|quater)|` +
+		`[a-zA-Z]{1,3}(?:\s*[-/]?\s*\d{1,4})?))?\.?$`)
+
+	// streetNumberFirst matches "27 rue de la Paix" and "12 bis, avenue Foch".
+	streetNumberFirst = regexp.MustCompile(`^(\d+)\s*(?:((?i:bis|ter|quater))\b|([a-zA-Z])\b)?[\s,]+(.*\p{L}.*)$`)
+)
+
+// ParseStreet splits an address line into street, house number and number
+// addition. Dutch, Belgian and German addresses put the number after the
+// street, so a leading number such as in "2e Rozendwarsstraat" is part of the
+// street name; French addresses usually put it first. Other countries try both
+// orders. ok is false when no house number was found.
+func ParseStreet(line string, country string) (street string, number string, addition string, ok bool) {
+	line = strings.Join(strings.Fields(line), " ")
+
+	switch strings.ToUpper(country) {
+	case "FR":
+		if street, number, addition, ok = parseNumberFirst(line); ok {
+			return
+		}
+
+		return parseNumberLast(line)
+	case "NL", "BE", "DE":
+		return parseNumberLast(line)
+	default:
+		if street, number, addition, ok = parseNumberLast(line); ok {
+			return
+		}
+
+		return parseNumberFirst(line)
+	}
+}
+
+func parseNumberLast(line string) (string, string, string, bool) {
+	m := streetNumberLast.FindStringSubmatch(line)
+	if m == nil {
+		return line, "", "", false
+	}
+
+	addition := m[3]
+	if addition == "" {
+		addition = m[4]
+	}
+
+	return strings.TrimRight(m[1], " ,"), m[2], normalizeAddition(addition), true
+}
+
+func parseNumberFirst(line string) (string, string, string, bool) {
+	m := streetNumberFirst.FindStringSubmatch(line)
+	if m == nil {
+		return line, "", "", false
+	}
+
+	addition := m[2]
+	if addition == "" {
+		addition = m[3]
+	}
+
+	return m[4], m[1], normalizeAddition(addition), true
+}
+
+func normalizeAddition(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+
+	lower := strings.ToLower(s)
+	for _, box := range []string{"boîte", "boite", "bte", "box", "bus"} {
+		if strings.HasPrefix(lower, box) {
+			return "bus " + strings.TrimSpace(s[len(box):])
+		}
+	}
+
+	switch lower {
+	case "bis", "ter", "quater":
+		return lower
+	}
+
+	return strings.ToUpper(s)
+}
+
+var (
+	zipcodeNL = regexp.MustCompile(`^(\d{4})([A-Z]{2})$`)
+	zipcodeGB = regexp.MustCompile(`^([A-Z]{1,2}\d[A-Z\d]?)(\d[A-Z]{2})$`)
+	zipDigits = map[string]int{"AT": 4, "BE": 4, "CH": 4, "DE": 5, "DK": 4, "ES": 5, "FR": 5, "IT": 5, "LU": 4, "NO": 4}
+
+	// zipLeadingZero holds the countries whose zipcodes can start with a 0.
+	zipLeadingZero = map[string]bool{"DE": true, "ES": true, "FR": true, "IT": true, "NO": true}
+)
+
+// NormalizeZipcode formats a zipcode the way it is written in the country,
+// e.g. "3331 mb" becomes "3331MB" in the Netherlands and "1067" becomes
+// "01067" in Germany. ok is false when the zipcode does not match the format
+// of a known country; the zipcode is then only trimmed and upper-cased.
+func NormalizeZipcode(zipcode string, country string) (normalized string, ok bool) {
+	country = strings.ToUpper(country)
+
+	z := strings.ToUpper(strings.Join(strings.Fields(zipcode), ""))
+	z = strings.TrimPrefix(z, country+"-")
+	z = strings.TrimPrefix(z, countryPrefixes[country]+"-")
+
+	fallback := strings.ToUpper(strings.Join(strings.Fields(zipcode), " "))
+
+	switch country {
+	case "NL":
+		if zipcodeNL.MatchString(z) {
+			return z, true
+		}
+	case "GB":
+		if m := zipcodeGB.FindStringSubmatch(z); m != nil {
+			return m[1] + " " + m[2], true
+		}
+	default:
+		n, known := zipDigits[country]
+		if !known {
+			return fallback, false
+		}
+
+		if !isDigits(z) {
+			break
+		}
+
+		if len(z) == n {
+			return z, true
+		}
+
+		// leading zeros are lost when zipcodes pass through spreadsheets, but
+		// only one is restored, and only where zipcodes can start with 0; a
+		// shorter zipcode is not guessed at
+		if len(z) == n-1 && zipLeadingZero[country] {
+			return "0" + z, true
+		}
+	}
+
+	return fallback, false
+}
+
+// countryPrefixes holds the old international vehicle codes used as zipcode
+// prefixes, e.g. "D-01067".
+var countryPrefixes = map[string]string{
+	"AT": "A", "BE": "B", "CH": "CH", "DE": "D", "DK": "DK", "ES": "E", "FR": "F", "IT": "I", "LU": "L", "NL": "NL", "NO": "N",
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// countryNames maps country names in English, Dutch, German and French, and
+// ISO 3166-1 alpha-3 codes, to alpha-2 codes. Keys are folded with foldName.
+var countryNames = map[string]string{
+	"netherlands": "NL", "nederland": "NL", "holland": "NL", "niederlande": "NL", "pays bas": "NL", "nld": "NL",
+	"belgium": "BE", "belgie": "BE", "belgique": "BE", "belgien": "BE", "bel": "BE",
+	"germany": "DE", "deutschland": "DE", "duitsland": "DE", "allemagne": "DE", "deu": "DE",
+	"france": "FR", "frankrijk": "FR", "frankreich": "FR", "fra": "FR",
+	"luxembourg": "LU", "luxemburg": "LU", "lux": "LU",
+	"united kingdom": "GB", "uk": "GB", "great britain": "GB", "england": "GB", "scotland": "GB", "wales": "GB",
+	"northern ireland": "GB", "verenigd koninkrijk": "GB", "engeland": "GB", "grossbritannien": "GB",
+	"vereinigtes konigreich": "GB", "royaume uni": "GB", "angleterre": "GB", "gbr": "GB",
+	"ireland": "IE", "ierland": "IE", "irland": "IE", "irlande": "IE", "irl": "IE",
+	"spain": "ES", "spanje": "ES", "spanien": "ES", "espagne": "ES", "espana": "ES", "esp": "ES",
+	"portugal": "PT", "prt": "PT",
+	"italy": "IT", "italie": "IT", "italien": "IT", "italia": "IT", "ita": "IT",
+	"austria": "AT", "oostenrijk": "AT", "osterreich": "AT", "autriche": "AT", "aut": "AT",
+	"switzerland": "CH", "zwitserland": "CH", "schweiz": "CH", "suisse": "CH", "svizzera": "CH", "che": "CH",
+	"denmark": "DK", "denemarken": "DK", "danemark": "DK", "danmark": "DK", "dnk": "DK",
+	"sweden": "SE", "zweden": "SE", "schweden": "SE", "suede": "SE", "sverige": "SE", "swe": "SE",
+	"norway": "NO", "noorwegen": "NO", "norwegen": "NO", "norvege": "NO", "norge": "NO", "nor": "NO",
+	"finland": "FI", "finnland": "FI", "finlande": "FI", "suomi": "FI", "fin": "FI",
+	"poland": "PL", "polen": "PL", "pologne": "PL", "polska": "PL", "pol": "PL",
+	"czech republic": "CZ", "czechia": "CZ", "tsjechie": "CZ", "tschechien": "CZ", "republique tcheque": "CZ", "cze": "CZ",
+	"slovakia": "SK", "slowakije": "SK", "slowakei": "SK", "slovaquie": "SK", "svk": "SK",
+	"hungary": "HU", "hongarije": "HU", "ungarn": "HU", "hongrie": "HU", "hun": "HU",
+	"greece": "GR", "griekenland": "GR", "griechenland": "GR", "grece": "GR", "grc": "GR",
+	"romania": "RO", "roemenie": "RO", "rumanien": "RO", "roumanie": "RO", "rou": "RO",
+	"bulgaria": "BG", "bulgarije": "BG", "bulgarien": "BG", "bulgarie": "BG", "bgr": "BG",
+	"croatia": "HR", "kroatie": "HR", "kroatien": "HR", "croatie": "HR", "hrv": "HR",
+	"slovenia": "SI", "slovenie": "SI", "slowenien": "SI", "svn": "SI",
+	"estonia": "EE", "estland": "EE", "estonie": "EE", "est": "EE",
+	"latvia": "LV", "letland": "LV", "lettland": "LV", "lettonie": "LV", "lva": "LV",
+	"lithuania": "LT", "litouwen": "LT", "litauen": "LT", "lituanie": "LT", "ltu": "LT",
+	"malta": "MT", "malte": "MT", "mlt": "MT",
+	"cyprus": "CY", "zypern": "CY", "chypre": "CY", "cyp": "CY",
+	"iceland": "IS", "ijsland": "IS", "island": "IS", "islande": "IS", "isl": "IS",
+	"liechtenstein": "LI", "lie": "LI",
+	"monaco": "MC", "mco": "MC",
+	"united states": "US", "united states of america": "US", "usa": "US", "america": "US",
+	"verenigde staten": "US", "vereinigte staaten": "US", "etats unis": "US",
+	"canada": "CA", "can": "CA",
+	"australia": "AU", "australie": "AU", "australien": "AU", "aus": "AU",
+	"new zealand": "NZ", "nieuw zeeland": "NZ", "neuseeland": "NZ", "nouvelle zelande": "NZ", "nzl": "NZ",
+	"japan": "JP", "japon": "JP", "jpn": "JP",
+	"china": "CN", "chine": "CN", "chn": "CN",
+	"turkey": "TR", "turkije": "TR", "turkei": "TR", "turquie": "TR", "turkiye": "TR", "tur": "TR",
+	"curacao": "CW", "cuw": "CW",
+	"aruba": "AW", "abw": "AW",
+	"suriname": "SR", "sur": "SR",
+}
+
+var foldReplacer = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ä", "a", "ã", "a", "å", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "ö", "o", "õ", "o", "ø", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n", "ß", "ss", "ý", "y", "ÿ", "y",
+	".", "", "'", "", "-", " ", "_", " ", ",", " ", "(", " ", ")", " ",
+)
+
+// foldName lower-cases a country name, removes diacritics and punctuation
+// and a leading "the".
+func foldName(name string) string {
+	s := strings.Join(strings.Fields(foldReplacer.Replace(strings.ToLower(name))), " ")
+
+	return strings.TrimPrefix(s, "the ")
+}
+
+// CountryCode returns the ISO 3166-1 alpha-2 code of a country given by its
+// code or by its name in English, Dutch, German or French.
+func CountryCode(name string) (code string, ok bool) {
+	if c := strings.ToUpper(strings.TrimSpace(name)); IsCountryCode(c) {
+		return c, true
+	}
+
+	code, ok = countryNames[foldName(name)]
+
+	return
+}
+
+// NormalizeAddress returns a copy of a with the country as ISO code, the
+// house number split from the street when StreetNumber is empty and the
+// zipcode formatted for the country. Parts that cannot be normalized are
+// left as they are.
+func NormalizeAddress(a ShippingAddress) ShippingAddress {
+	a.Country = strings.TrimSpace(a.Country)
+	if code, ok := CountryCode(a.Country); ok {
+		a.Country = code
+	}
+
+	a.Street = strings.Join(strings.Fields(a.Street), " ")
+	if a.StreetNumber == "" {
+		if street, number, addition, ok := ParseStreet(a.Street, a.Country); ok {
+			a.Street = street
+			a.StreetNumber = number
+
+			if a.StreetNumberAddition == "" {
+				a.StreetNumberAddition = addition
+			}
+		}
+	}
+
+	if zipcode, ok := NormalizeZipcode(a.Zipcode, a.Country); ok {
+		a.Zipcode = zipcode
+	}
+
+	a.City = strings.Join(strings.Fields(a.City), " ")
+
+	return a
+}
@@ -0,0 +1,262 @@
+package ewhs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStreet(t *testing.T) {
+	tests := []struct {
+		line     string
+		country  string
+		street   string
+		number   string
+		addition string
+		ok       bool
+	}{
+		// Netherlands
+		{"Nijverheidsweg 27a", "NL", "Nijverheidsweg", "27", "A", true},
+		{"Nijverheidsweg 27", "NL", "Nijverheidsweg", "27", "", true},
+		{"Nijverheidsweg 27 A", "NL", "Nijverheidsweg", "27", "A", true},
+		{"Nijverheidsweg 27-A", "NL", "Nijverheidsweg", "27", "A", true},
+		{"Nijverheidsweg  27   a ", "NL", "Nijverheidsweg", "27", "A", true},
+		{"Kerkstraat 12-2", "NL", "Kerkstraat", "12", "2", true},
+		{"Kerkstraat 12/3", "NL", "Kerkstraat", "12", "3", true},
+		{"Kerkstraat 12A-2", "NL", "Kerkstraat", "12", "A-2", true},
+		{"Kerkstraat 12 hs", "NL", "Kerkstraat", "12", "HS", true},
+		{"Kerkstraat 12-bis", "NL", "Kerkstraat", "12", "bis", true},
+		{"Kerkstraat 12 III", "NL", "Kerkstraat", "12", "III", true},
+		{"Kerkstraat, 12", "NL", "Kerkstraat", "12", "", true},
+		{"1e Hugo de Grootstraat 5", "NL", "1e Hugo de Grootstraat", "5", "", true},
+		{"2e Jan van der Heijdenstraat 45 hs", "NL", "2e Jan van der Heijdenstraat", "45", "HS", true},
+		{"Plein 1944 12", "NL", "Plein 1944", "12", "", true},
+		{"Postbus 123", "NL", "Postbus", "123", "", true},
+		{"Van Heenvlietlaan 220", "NL", "Van Heenvlietlaan", "220", "", true},
+		{"'s-Gravendijkwal 3c", "NL", "'s-Gravendijkwal", "3", "C", true},
+		{"Zuidzijde", "NL", "Zuidzijde", "", "", false},
+		{"2e Rozendwarsstraat", "NL", "2e Rozendwarsstraat", "", "", false},
+
+		// Belgium
+		{"Meir 50", "BE", "Meir", "50", "", true},
+		{"Rue de la Loi 16", "BE", "Rue de la Loi", "16", "", true},
+		{"Grote Markt 7 bus 3", "BE", "Grote Markt", "7", "bus 3", true},
+		{"Grote Markt 7 bus 12B", "BE", "Grote Markt", "7", "bus 12B", true},
+		{"Grote Markt 7/bus 3", "BE", "Grote Markt", "7", "bus 3", true},
+		{"Avenue Louise 149 boîte 24", "BE", "Avenue Louise", "149", "bus 24", true},
+		{"Avenue Louise 149 bte 24", "BE", "Avenue Louise", "149", "bus 24", true},
+		{"Chaussée de Wavre 12 box 1", "BE", "Chaussée de Wavre", "12", "bus 1", true},
+		{"Boulevard Anspach 1 bis", "BE", "Boulevard Anspach", "1", "bis", true},
+
+		// Germany
+		{"Hauptstraße 5", "DE", "Hauptstraße", "5", "", true},
+		{"Hauptstr. 5b", "DE", "Hauptstr.", "5", "B", true},
+		{"Hauptstraße 5-7", "DE", "Hauptstraße", "5", "7", true},
+		{"Straße des 17. Juni 135", "DE", "Straße des 17. Juni", "135", "", true},
+		{"Am Kupfergraben 6a", "DE", "Am Kupfergraben", "6", "A", true},
+		{"Müllerstraße 12 c", "DE", "Müllerstraße", "12", "C", true},
+		{"Platz der Republik 1", "DE", "Platz der Republik", "1", "", true},
+		{"Unter den Linden 77", "DE", "Unter den Linden", "77", "", true},
+		{"1 Maistraße", "DE", "1 Maistraße", "", "", false},
+
+		// France
+		{"27 rue de la Paix", "FR", "rue de la Paix", "27", "", true},
+		{"27 bis rue de la Paix", "FR", "rue de la Paix", "27", "bis", true},
+		{"27bis rue de la Paix", "FR", "rue de la Paix", "27", "bis", true},
+		{"12 ter, avenue Foch", "FR", "avenue Foch", "12", "ter", true},
+		{"5 quater boulevard Haussmann", "FR", "boulevard Haussmann", "5", "quater", true},
+		{"8B place Bellecour", "FR", "place Bellecour", "8", "B", true},
+		{"8 b place Bellecour", "FR", "place Bellecour", "8", "B", true},
+		{"1, avenue des Champs-Élysées", "FR", "avenue des Champs-Élysées", "1", "", true},
+		{"Rue de Rivoli 99", "FR", "Rue de Rivoli", "99", "", true},
+		{"Place Vendôme", "FR", "Place Vendôme", "", "", false},
+
+		// other countries try both orders
+		{"10 Downing Street", "GB", "Downing Street", "10", "", true},
+		{"Bahnhofstrasse 1", "CH", "Bahnhofstrasse", "1", "", true},
+		{"Nijverheidsweg 27a", "", "Nijverheidsweg", "27", "A", true},
+		{"", "NL", "", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.country+"/"+tt.line, func(t *testing.T) {
+			street, number, addition, ok := ParseStreet(tt.line, tt.country)
+			assert.Equal(t, tt.street, street, "street")
+			assert.Equal(t, tt.number, number, "number")
+			assert.Equal(t, tt.addition, addition, "addition")
+			assert.Equal(t, tt.ok, ok, "ok")
+		})
+	}
+}
+
+func TestNormalizeZipcode(t *testing.T) {
+	tests := []struct {
+		zipcode string
+		country string
+		want    string
+		ok      bool
+	}{
+		{"3331 mb", "NL", "3331MB", true},
+		{"3331MB", "NL", "3331MB", true},
+		{" 3331  Mb ", "NL", "3331MB", true},
+		{"NL-3331 MB", "NL", "3331MB", true},
+		{"3331", "NL", "3331", false},
+		{"33311 MB", "NL", "33311 MB", false},
+		{"2000", "BE", "2000", true},
+		{"B-2000", "BE", "2000", true},
+		{"BE-1000", "BE", "1000", true},
+		{"20000", "BE", "20000", false},
+		{"999", "BE", "999", false},
+		{"10117", "DE", "10117", true},
+		{"1067", "DE", "01067", true},
+		{"D-01067", "DE", "01067", true},
+		{"DE-10117", "DE", "10117", true},
+		{"101", "DE", "101", false},
+		{"75002", "FR", "75002", true},
+		{"1000", "FR", "01000", true},
+		{"F-75002", "FR", "75002", true},
+		{"75 002", "FR", "75002", true},
+		{"L-1234", "LU", "1234", true},
+		{"A-1010", "AT", "1010", true},
+		{"8001", "CH", "8001", true},
+		{"801", "CH", "801", false},
+		{"999", "AT", "999", false},
+		{"123", "LU", "123", false},
+		{"800", "DK", "800", false},
+		{"150", "NO", "0150", true},
+		{"0150", "NO", "0150", true},
+		{"1234", "ES", "01234", true},
+		{"0100", "IT", "00100", true},
+		{"28013", "ES", "28013", true},
+		{"sw1a1aa", "GB", "SW1A 1AA", true},
+		{"SW1A 1AA", "GB", "SW1A 1AA", true},
+		{"ec1a 1bb", "GB", "EC1A 1BB", true},
+		{"m1 1ae", "GB", "M1 1AE", true},
+		{"b33 8th", "GB", "B33 8TH", true},
+		{"not a zip", "GB", "NOT A ZIP", false},
+		{"10001", "US", "10001", false},
+		{" k1a  0b1 ", "CA", "K1A 0B1", false},
+		{"3331 mb", "nl", "3331MB", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.country+"/"+tt.zipcode, func(t *testing.T) {
+			got, ok := NormalizeZipcode(tt.zipcode, tt.country)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
+
+func TestCountryCode(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+		ok   bool
+	}{
+		{"NL", "NL", true},
+		{"nl", "NL", true},
+		{" be ", "BE", true},
+		{"Netherlands", "NL", true},
+		{"The Netherlands", "NL", true},
+		{"the netherlands", "NL", true},
+		{"Nederland", "NL", true},
+		{"Holland", "NL", true},
+		{"Niederlande", "NL", true},
+		{"Pays-Bas", "NL", true},
+		{"NLD", "NL", true},
+		{"België", "BE", true},
+		{"Belgie", "BE", true},
+		{"Belgique", "BE", true},
+		{"Belgien", "BE", true},
+		{"Belgium", "BE", true},
+		{"Deutschland", "DE", true},
+		{"Duitsland", "DE", true},
+		{"Germany", "DE", true},
+		{"Allemagne", "DE", true},
+		{"DEU", "DE", true},
+		{"France", "FR", true},
+		{"Frankrijk", "FR", true},
+		{"Frankreich", "FR", true},
+		{"Luxemburg", "LU", true},
+		{"United Kingdom", "GB", true},
+		{"U.K.", "GB", true},
+		{"Great Britain", "GB", true},
+		{"Großbritannien", "GB", true},
+		{"Royaume-Uni", "GB", true},
+		{"Verenigd Koninkrijk", "GB", true},
+		{"Österreich", "AT", true},
+		{"Oostenrijk", "AT", true},
+		{"Schweiz", "CH", true},
+		{"Suisse", "CH", true},
+		{"España", "ES", true},
+		{"Spanje", "ES", true},
+		{"Italië", "IT", true},
+		{"Danemark", "DK", true},
+		{"Suède", "SE", true},
+		{"Polska", "PL", true},
+		{"Czech Republic", "CZ", true},
+		{"Tsjechië", "CZ", true},
+		{"United States of America", "US", true},
+		{"U.S.A.", "US", true},
+		{"États-Unis", "US", true},
+		{"Curaçao", "CW", true},
+		{"Türkiye", "TR", true},
+		{"Atlantis", "", false},
+		{"XX", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := CountryCode(tt.name)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
+
+func TestNormalizeAddress(t *testing.T) {
+	tests := []struct {
+		name string
+		in   ShippingAddress
+		want ShippingAddress
+	}{
+		{
+			"webshop address",
+			ShippingAddress{Street: "Nijverheidsweg 27a", Zipcode: "3331 mb", City: " Heinenoord ", Country: "Nederland"},
+			ShippingAddress{Street: "Nijverheidsweg", StreetNumber: "27", StreetNumberAddition: "A", Zipcode: "3331MB", City: "Heinenoord", Country: "NL"},
+		},
+		{
+			"french address",
+			ShippingAddress{Street: "27 bis rue de la Paix", Zipcode: "F-75002", City: "Paris", Country: "France"},
+			ShippingAddress{Street: "rue de la Paix", StreetNumber: "27", StreetNumberAddition: "bis", Zipcode: "75002", City: "Paris", Country: "FR"},
+		},
+		{
+			"split address is kept",
+			ShippingAddress{Street: "Plein 1944", StreetNumber: "12", Zipcode: "6511 aa", Country: "NL"},
+			ShippingAddress{Street: "Plein 1944", StreetNumber: "12", Zipcode: "6511AA", Country: "NL"},
+		},
+		{
+			"addition is kept",
+			ShippingAddress{Street: "Grote Markt 7", StreetNumberAddition: "bus 3", Zipcode: "B-2000", Country: "BE"},
+			ShippingAddress{Street: "Grote Markt", StreetNumber: "7", StreetNumberAddition: "bus 3", Zipcode: "2000", Country: "BE"},
+		},
+		{
+			"zipcode that cannot be normalized is kept",
+			ShippingAddress{Street: "Hauptstraße 1", Zipcode: " 101 ", Country: "DE"},
+			ShippingAddress{Street: "Hauptstraße", StreetNumber: "1", Zipcode: " 101 ", Country: "DE"},
+		},
+		{
+			"unknown country",
+			ShippingAddress{Street: "Main Street", Zipcode: "12345", Country: "Atlantis"},
+			ShippingAddress{Street: "Main Street", Zipcode: "12345", Country: "Atlantis"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NormalizeAddress(tt.in))
+		})
+	}
+}
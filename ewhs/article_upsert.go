@@ -0,0 +1,343 @@
+package ewhs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var errArticleIDRequired = errors.New("article ID required to add a variant to an existing article")
+
+type UpsertAction string
+
+const (
+	UpsertCreated   UpsertAction = "created"
+	UpsertUpdated   UpsertAction = "updated"
+	UpsertUnchanged UpsertAction = "unchanged"
+)
+
+// FieldChange is a field that was changed by an upsert. Field is the JSON
+// name of the field.
+type FieldChange struct {
+	Field string
+	Old   interface{}
+	New   interface{}
+}
+
+// VariantUpsert is the outcome of an upsert for one variant.
+type VariantUpsert struct {
+	ArticleCode string
+	VariantID   string
+	Action      UpsertAction
+	Changes     []FieldChange
+}
+
+// ArticleUpsert is the outcome of ArticlesService.Upsert. Changes holds the
+// changes to the article itself, the variants have their own.
+type ArticleUpsert struct {
+	ArticleID string
+	Action    UpsertAction
+	Changes   []FieldChange
+	Variants  []VariantUpsert
+}
+
+// Changed reports whether the article or any of its variants was created or
+// updated.
+func (u *ArticleUpsert) Changed() bool {
+	return u.Action != UpsertUnchanged
+}
+
+// Upsert creates the article, or updates the existing variants to match it.
+// Every variant of art is looked up by its article code, or otherwise by its
+// EAN, and the fields that differ are patched. Fields that are empty in art
+// are left alone, so a partial article only changes what it holds; use
+// VariantsService.Patch to clear a field. Expirable and UsingSerialNumbers
+// cannot be empty and are always compared, so they are turned off when art
+// has them off.
+//
+// The article is created when none of its variants exists yet. The name of
+// an existing article is only compared, and new variants are only added to
+// it, when art.ID is set, as variants do not refer to their article. The
+// result lists every field that was changed, so calling Upsert again with the
+// same article changes nothing.
+func (as *ArticlesService) Upsert(ctx context.Context, art Article) (*ArticleUpsert, error) {
+	if err := art.Validate(); err != nil {
+		return nil, err
+	}
+
+	found := make([]*Variant, len(art.Variants))
+	exists := false
+
+	for i, want := range art.Variants {
+		cur, err := as.findVariant(ctx, want)
+		if err != nil {
+			return nil, err
+		}
+
+		found[i] = cur
+		exists = exists || cur != nil
+	}
+
+	if art.ID == "" && !exists {
+		created, _, err := as.Create(ctx, art)
+		if err != nil {
+			return nil, err
+		}
+
+		u := &ArticleUpsert{Action: UpsertCreated}
+		if created != nil {
+			u.ArticleID = created.ID
+		}
+
+		for _, v := range art.Variants {
+			u.Variants = append(u.Variants, VariantUpsert{ArticleCode: v.ArticleCode, Action: UpsertCreated})
+		}
+
+		return u, nil
+	}
+
+	var existing *Article
+
+	if art.ID != "" {
+		var err error
+		if existing, _, err = as.Get(ctx, art.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	var added []ArticleVariant
+
+	for i, want := range art.Variants {
+		if found[i] == nil {
+			if existing == nil {
+				return nil, fmt.Errorf("variant %s: %w", want.ArticleCode, errArticleIDRequired)
+			}
+
+			added = append(added, want)
+		}
+	}
+
+	u := &ArticleUpsert{ArticleID: art.ID, Action: UpsertUnchanged}
+
+	for i, want := range art.Variants {
+		if found[i] == nil {
+			u.Variants = append(u.Variants, VariantUpsert{ArticleCode: want.ArticleCode, Action: UpsertCreated})
+			continue
+		}
+
+		vu, err := as.upsertVariant(ctx, *found[i], want)
+		if err != nil {
+			return nil, err
+		}
+
+		if vu.Action != UpsertUnchanged {
+			u.Action = UpsertUpdated
+		}
+
+		u.Variants = append(u.Variants, vu)
+	}
+
+	if existing == nil {
+		return u, nil
+	}
+
+	var upd ArticleUpdate
+	diffField(&u.Changes, "name", existing.Name, art.Name, &upd.Name)
+
+	if len(added) > 0 {
+		// the existing variants are sent along, so none is dropped if the
+		// API replaces the variants of the article
+		upd.Variants.Set(articleVariantUpdates(append(append([]ArticleVariant{}, existing.Variants...), added...)))
+	}
+
+	if upd.Name.IsSpecified() || upd.Variants.IsSpecified() {
+		if _, _, err := as.Patch(ctx, existing.ID, upd); err != nil {
+			return nil, err
+		}
+
+		u.Action = UpsertUpdated
+	}
+
+	return u, nil
+}
+
+// UpsertMany upserts the articles with bounded concurrency, e.g. to push a
+// complete catalog. Articles that share an ID, article code or EAN are
+// upserted one after the other, so they do not overwrite each other's changes
+// and a variant listed twice is not created twice.
+func (as *ArticlesService) UpsertMany(ctx context.Context, arts []Article, opts *BatchOptions) *BatchResult[Article, *ArticleUpsert] {
+	locks := &keyLocks{locks: map[string]*sync.Mutex{}}
+
+	return RunBatch(ctx, arts, opts, func(ctx context.Context, art Article) (*ArticleUpsert, *Response, error) {
+		unlock := locks.lock(upsertKeys(art))
+		defer unlock()
+
+		u, err := as.Upsert(ctx, art)
+		return u, nil, err
+	})
+}
+
+// findVariant returns the variant with the article code of want, or else the
+// one with its EAN, or nil when there is none.
+func (as *ArticlesService) findVariant(ctx context.Context, want ArticleVariant) (*Variant, error) {
+	variant, _, err := as.client.Variants.FindByArticleCode(ctx, want.ArticleCode)
+	if err == nil && variant == nil && want.Ean != "" {
+		variant, _, err = as.client.Variants.FindByEan(ctx, want.Ean)
+	}
+
+	return variant, err
+}
+
+func (as *ArticlesService) upsertVariant(ctx context.Context, cur Variant, want ArticleVariant) (VariantUpsert, error) {
+	vu := VariantUpsert{ArticleCode: want.ArticleCode, VariantID: cur.ID, Action: UpsertUnchanged}
+
+	var upd VariantUpdate
+	diffField(&vu.Changes, "article_code", cur.ArticleCode, want.ArticleCode, &upd.ArticleCode)
+	diffField(&vu.Changes, "name", cur.Name, want.Name, &upd.Name)
+	diffField(&vu.Changes, "description", cur.Description, want.Description, &upd.Description)
+	diffField(&vu.Changes, "ean", cur.Ean, want.Ean, &upd.Ean)
+	diffField(&vu.Changes, "sku", cur.Sku, want.Sku, &upd.Sku)
+	diffField(&vu.Changes, "hs_tariff_code", cur.HsTariffCode, want.HsTariffCode, &upd.HsTariffCode)
+	diffField(&vu.Changes, "height", cur.Height, int64(want.Height), &upd.Height)
+	diffField(&vu.Changes, "depth", cur.Depth, int64(want.Depth), &upd.Depth)
+	diffField(&vu.Changes, "width", cur.Width, int64(want.Width), &upd.Width)
+	diffField(&vu.Changes, "weight", cur.Weight, int64(want.Weight), &upd.Weight)
+	diffValue(&vu.Changes, "expirable", cur.Expirable, want.Expirable, &upd.Expirable)
+	diffField(&vu.Changes, "country_of_origin", cur.CountryOfOrigin, want.CountryOfOrigin, &upd.CountryOfOrigin)
+	diffValue(&vu.Changes, "using_serial_numbers", cur.UsingSerialNumbers, want.UsingSerialNumbers, &upd.UsingSerialNumbers)
+
+	if want.Value != "" && !cur.Value.Equal(want.Value) {
+		vu.Changes = append(vu.Changes, FieldChange{Field: "value", Old: cur.Value, New: want.Value})
+		upd.Value.Set(want.Value)
+	}
+
+	if len(vu.Changes) == 0 {
+		return vu, nil
+	}
+
+	if _, _, err := as.client.Variants.Patch(ctx, cur.ID, upd); err != nil {
+		return vu, err
+	}
+
+	vu.Action = UpsertUpdated
+
+	return vu, nil
+}
+
+// diffField records a change and sets it on the update when new is not the
+// zero value and differs from old.
+func diffField[T comparable](changes *[]FieldChange, field string, old T, new T, set *Nullable[T]) {
+	var zero T
+	if new == zero {
+		return
+	}
+
+	diffValue(changes, field, old, new, set)
+}
+
+// diffValue records a change and sets it on the update when new differs from
+// old, including when new is the zero value.
+func diffValue[T comparable](changes *[]FieldChange, field string, old T, new T, set *Nullable[T]) {
+	if old == new {
+		return
+	}
+
+	*changes = append(*changes, FieldChange{Field: field, Old: old, New: new})
+	set.Set(new)
+}
+
+// articleVariantUpdates converts variants for an ArticleUpdate, leaving out
+// empty fields as the variants do when they are sent themselves.
+func articleVariantUpdates(variants []ArticleVariant) []ArticleVariantUpdate {
+	list := make([]ArticleVariantUpdate, 0, len(variants))
+
+	for _, v := range variants {
+		var u ArticleVariantUpdate
+		setNonZero(&u.Name, v.Name)
+		setNonZero(&u.ArticleCode, v.ArticleCode)
+		setNonZero(&u.Description, v.Description)
+		setNonZero(&u.Ean, v.Ean)
+		setNonZero(&u.Sku, v.Sku)
+		setNonZero(&u.HsTariffCode, v.HsTariffCode)
+		setNonZero(&u.Height, v.Height)
+		setNonZero(&u.Depth, v.Depth)
+		setNonZero(&u.Width, v.Width)
+		setNonZero(&u.Weight, v.Weight)
+		setNonZero(&u.Expirable, v.Expirable)
+		setNonZero(&u.CountryOfOrigin, v.CountryOfOrigin)
+		setNonZero(&u.UsingSerialNumbers, v.UsingSerialNumbers)
+		setNonZero(&u.Value, v.Value)
+
+		list = append(list, u)
+	}
+
+	return list
+}
+
+func setNonZero[T comparable](n *Nullable[T], v T) {
+	var zero T
+	if v != zero {
+		n.Set(v)
+	}
+}
+
+// upsertKeys returns the keys an upsert of art may touch: its ID and the
+// article codes and EANs of its variants.
+func upsertKeys(art Article) []string {
+	var keys []string
+	if art.ID != "" {
+		keys = append(keys, "id:"+art.ID)
+	}
+
+	for _, v := range art.Variants {
+		if v.ArticleCode != "" {
+			keys = append(keys, "article_code:"+v.ArticleCode)
+		}
+
+		if v.Ean != "" {
+			keys = append(keys, "ean:"+v.Ean)
+		}
+	}
+
+	return keys
+}
+
+// keyLocks holds a mutex per key.
+type keyLocks struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// lock locks the mutexes of keys, in sorted order so that two callers cannot
+// deadlock, and returns a func that unlocks them.
+func (kl *keyLocks) lock(keys []string) func() {
+	keys = append([]string{}, keys...)
+	sort.Strings(keys)
+
+	var held []*sync.Mutex
+
+	for i, key := range keys {
+		if i > 0 && key == keys[i-1] {
+			continue
+		}
+
+		kl.mu.Lock()
+		l, ok := kl.locks[key]
+		if !ok {
+			l = &sync.Mutex{}
+			kl.locks[key] = l
+		}
+		kl.mu.Unlock()
+
+		l.Lock()
+		held = append(held, l)
+	}
+
+	return func() {
+		for _, l := range held {
+			l.Unlock()
+		}
+	}
+}
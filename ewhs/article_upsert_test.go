@@ -0,0 +1,369 @@
+package ewhs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const upsertArticleResponse = `{
+  "id": "a1",
+  "name": "Jacket",
+  "variants": [
+    {"article_code": "green_jacket", "name": "Green jacket", "ean": "8712345678906", "weight": 800, "expirable": true, "value": 49.95},
+    {"article_code": "red_jacket", "name": "Red jacket", "ean": "8712345678913", "weight": 800, "value": 49.95}
+  ]
+}`
+
+func jacket() Article {
+	return Article{
+		Name: "Jacket",
+		Variants: []ArticleVariant{
+			{ArticleCode: "green_jacket", Name: "Green jacket", Ean: "8712345678906", Weight: 800, Expirable: true, Value: "49.95"},
+			{ArticleCode: "red_jacket", Name: "Red jacket", Ean: "8712345678913", Weight: 800, Value: "49.95"},
+		},
+	}
+}
+
+// upsertCalls records the bodies of the requests that change something,
+// keyed by method and path, and the number of articles created.
+type upsertCalls struct {
+	mu      sync.Mutex
+	bodies  map[string]string
+	creates int
+}
+
+// upsertServer serves the article above and its variants, which are looked
+// up by article code or EAN. Created articles add their variants.
+func upsertServer(t *testing.T) *upsertCalls {
+	t.Helper()
+
+	calls := &upsertCalls{bodies: map[string]string{}}
+	variants := []Variant{
+		{ID: "v1", ArticleCode: "green_jacket", Name: "Green jacket", Ean: "8712345678906", Weight: 800, Expirable: true, Value: "49.95"},
+		{ID: "v2", ArticleCode: "red_jacket", Name: "Red jacket", Ean: "8712345678913", Weight: 800, Value: "49.95"},
+	}
+
+	record := func(r *http.Request) []byte {
+		b, _ := io.ReadAll(r.Body)
+		calls.bodies[r.Method+" "+r.URL.Path] = strings.TrimSpace(string(b))
+
+		return b
+	}
+
+	tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+	tMux.HandleFunc("/wms/articles/", func(w http.ResponseWriter, r *http.Request) {
+		calls.mu.Lock()
+		defer calls.mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/wms/articles/a1/":
+			_, _ = w.Write([]byte(upsertArticleResponse))
+		case r.Method == http.MethodGet:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		case r.Method == http.MethodPost:
+			var art Article
+			assert.Nil(t, json.Unmarshal(record(r), &art))
+
+			calls.creates++
+			for _, v := range art.Variants {
+				variants = append(variants, Variant{ID: "new-" + v.ArticleCode, ArticleCode: v.ArticleCode, Name: v.Name, Ean: v.Ean})
+			}
+
+			_, _ = w.Write([]byte(`{"id":"a2"}`))
+		default:
+			record(r)
+			_, _ = w.Write([]byte(`{"id":"a1"}`))
+		}
+	})
+	tMux.HandleFunc("/wms/variants/", func(w http.ResponseWriter, r *http.Request) {
+		calls.mu.Lock()
+		defer calls.mu.Unlock()
+
+		if r.Method != http.MethodGet {
+			record(r)
+			_, _ = w.Write([]byte(`{}`))
+
+			return
+		}
+
+		code, ean := r.URL.Query().Get("article_code"), r.URL.Query().Get("ean")
+
+		list := []Variant{}
+		for _, v := range variants {
+			if (code != "" && v.ArticleCode == code) || (ean != "" && v.Ean == ean) {
+				list = append(list, v)
+			}
+		}
+
+		b, _ := json.Marshal(list)
+		_, _ = w.Write(b)
+	})
+
+	return calls
+}
+
+func TestArticlesService_Upsert(t *testing.T) {
+	tests := []struct {
+		name         string
+		modify       func(a *Article)
+		wantErr      error
+		wantAction   UpsertAction
+		wantChanges  []FieldChange
+		wantVariants []VariantUpsert
+		wantCalls    map[string]string
+	}{
+		{
+			"unchanged article",
+			func(a *Article) {},
+			nil,
+			UpsertUnchanged,
+			nil,
+			[]VariantUpsert{
+				{ArticleCode: "green_jacket", VariantID: "v1", Action: UpsertUnchanged},
+				{ArticleCode: "red_jacket", VariantID: "v2", Action: UpsertUnchanged},
+			},
+			map[string]string{},
+		},
+		{
+			"changed variant fields are patched",
+			func(a *Article) {
+				a.Variants[1].Weight = 750
+				a.Variants[1].Value = "39.95"
+				a.Variants[1].Expirable = true
+			},
+			nil,
+			UpsertUpdated,
+			nil,
+			[]VariantUpsert{
+				{ArticleCode: "green_jacket", VariantID: "v1", Action: UpsertUnchanged},
+				{ArticleCode: "red_jacket", VariantID: "v2", Action: UpsertUpdated, Changes: []FieldChange{
+					{Field: "weight", Old: int64(800), New: int64(750)},
+					{Field: "expirable", Old: false, New: true},
+					{Field: "value", Old: MajorUnits("49.95"), New: MajorUnits("39.95")},
+				}},
+			},
+			map[string]string{
+				"PATCH /wms/variants/v2/": `{"weight":750,"expirable":true,"value":39.95}`,
+			},
+		},
+		{
+			"empty fields are left alone",
+			func(a *Article) {
+				a.Variants = []ArticleVariant{{ArticleCode: "red_jacket", Name: "Red jacket", Weight: 750, Value: "49.950"}}
+			},
+			nil,
+			UpsertUpdated,
+			nil,
+			[]VariantUpsert{
+				{ArticleCode: "red_jacket", VariantID: "v2", Action: UpsertUpdated, Changes: []FieldChange{
+					{Field: "weight", Old: int64(800), New: int64(750)},
+				}},
+			},
+			map[string]string{
+				"PATCH /wms/variants/v2/": `{"weight":750}`,
+			},
+		},
+		{
+			"flags are turned off",
+			func(a *Article) {
+				a.Variants = []ArticleVariant{{ArticleCode: "green_jacket", Name: "Green jacket"}}
+			},
+			nil,
+			UpsertUpdated,
+			nil,
+			[]VariantUpsert{
+				{ArticleCode: "green_jacket", VariantID: "v1", Action: UpsertUpdated, Changes: []FieldChange{
+					{Field: "expirable", Old: true, New: false},
+				}},
+			},
+			map[string]string{
+				"PATCH /wms/variants/v1/": `{"expirable":false}`,
+			},
+		},
+		{
+			"variant matched by EAN gets a new article code",
+			func(a *Article) {
+				a.Variants[0].ArticleCode = "jacket_green"
+			},
+			nil,
+			UpsertUpdated,
+			nil,
+			[]VariantUpsert{
+				{ArticleCode: "jacket_green", VariantID: "v1", Action: UpsertUpdated, Changes: []FieldChange{
+					{Field: "article_code", Old: "green_jacket", New: "jacket_green"},
+				}},
+				{ArticleCode: "red_jacket", VariantID: "v2", Action: UpsertUnchanged},
+			},
+			map[string]string{
+				"PATCH /wms/variants/v1/": `{"article_code":"jacket_green"}`,
+			},
+		},
+		{
+			"renamed article with a new variant",
+			func(a *Article) {
+				a.ID = "a1"
+				a.Name = "Rain jacket"
+				a.Variants = append(a.Variants, ArticleVariant{ArticleCode: "blue_jacket", Name: "Blue jacket"})
+			},
+			nil,
+			UpsertUpdated,
+			[]FieldChange{{Field: "name", Old: "Jacket", New: "Rain jacket"}},
+			[]VariantUpsert{
+				{ArticleCode: "green_jacket", VariantID: "v1", Action: UpsertUnchanged},
+				{ArticleCode: "red_jacket", VariantID: "v2", Action: UpsertUnchanged},
+				{ArticleCode: "blue_jacket", Action: UpsertCreated},
+			},
+			map[string]string{
+				"PATCH /wms/articles/a1/": `{"name":"Rain jacket","variants":[` +
+					`{"name":"Green jacket","article_code":"green_jacket","ean":"8712345678906","weight":800,"expirable":true,"value":49.95},` +
+					`{"name":"Red jacket","article_code":"red_jacket","ean":"8712345678913","weight":800,"value":49.95},` +
+					`{"name":"Blue jacket","article_code":"blue_jacket"}]}`,
+			},
+		},
+		{
+			"new variant of an existing article needs its ID",
+			func(a *Article) {
+				a.Variants = append(a.Variants, ArticleVariant{ArticleCode: "blue_jacket", Name: "Blue jacket"})
+			},
+			errArticleIDRequired,
+			"",
+			nil,
+			nil,
+			map[string]string{},
+		},
+		{
+			"unknown article is created",
+			func(a *Article) {
+				a.Name = "Scarf"
+				a.Variants = []ArticleVariant{{ArticleCode: "scarf", Name: "Scarf"}}
+			},
+			nil,
+			UpsertCreated,
+			nil,
+			[]VariantUpsert{{ArticleCode: "scarf", Action: UpsertCreated}},
+			map[string]string{
+				"POST /wms/articles/": `{"name":"Scarf","variants":[{"name":"Scarf","article_code":"scarf"}]}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup()
+			defer teardown()
+
+			calls := upsertServer(t)
+
+			art := jacket()
+			tt.modify(&art)
+
+			u, err := tClient.Articles.Upsert(context.Background(), art)
+			assert.Equal(t, tt.wantCalls, calls.bodies)
+
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantAction, u.Action)
+			assert.Equal(t, tt.wantAction != UpsertUnchanged, u.Changed())
+			assert.Equal(t, tt.wantChanges, u.Changes)
+			assert.Equal(t, tt.wantVariants, u.Variants)
+
+			switch {
+			case tt.wantAction == UpsertCreated:
+				assert.Equal(t, "a2", u.ArticleID)
+			case art.ID != "":
+				assert.Equal(t, "a1", u.ArticleID)
+			}
+		})
+	}
+}
+
+func TestArticlesService_UpsertMany(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := upsertServer(t)
+
+	renamed := jacket()
+	renamed.ID = "a1"
+	renamed.Name = "Rain jacket"
+
+	heavier := Article{Name: "Jacket", Variants: []ArticleVariant{{ArticleCode: "red_jacket", Name: "Red jacket", Weight: 900}}}
+
+	scarf := Article{Name: "Scarf", Variants: []ArticleVariant{{ArticleCode: "scarf", Name: "Scarf", Ean: "8712345678920"}}}
+	wool := Article{Name: "Scarf", Variants: []ArticleVariant{{ArticleCode: "wool_scarf", Name: "Wool scarf", Ean: "8712345678920"}}}
+
+	res := tClient.Articles.UpsertMany(context.Background(), []Article{renamed, heavier, scarf, wool, {Name: "Invalid"}}, &BatchOptions{Concurrency: 5})
+
+	assert.Equal(t, UpsertUpdated, res.Items[0].Value.Action)
+	assert.Equal(t, UpsertUpdated, res.Items[1].Value.Action)
+	assert.NotNil(t, res.Items[4].Err)
+	assert.ErrorContains(t, res.Err(), "#4: ")
+	assert.Len(t, res.Failed(), 1)
+
+	// the scarves share an EAN: one creates the article, the other then finds
+	// its variant by EAN and updates it
+	assert.Equal(t, 1, calls.creates)
+	assert.ElementsMatch(t, []UpsertAction{UpsertCreated, UpsertUpdated}, []UpsertAction{res.Items[2].Value.Action, res.Items[3].Value.Action})
+
+	assert.Equal(t, `{"weight":900}`, calls.bodies["PATCH /wms/variants/v2/"])
+	assert.Contains(t, calls.bodies, "PATCH /wms/articles/a1/")
+
+	patched := 0
+	for call := range calls.bodies {
+		if strings.HasPrefix(call, "PATCH /wms/variants/new-") {
+			patched++
+		}
+	}
+
+	assert.Equal(t, 1, patched)
+}
+
+func TestKeyLocks(t *testing.T) {
+	locks := &keyLocks{locks: map[string]*sync.Mutex{}}
+
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func(keys []string) {
+			defer wg.Done()
+
+			unlock := locks.lock(keys)
+			defer unlock()
+
+			mu.Lock()
+			inFlight++
+			if inFlight > maxInFlight {
+				maxInFlight = inFlight
+			}
+			mu.Unlock()
+
+			time.Sleep(time.Millisecond)
+
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}([]string{"ean:1", "article_code:" + strconv.Itoa(i), "ean:1"})
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, 1, maxInFlight, "upserts sharing a key run one after the other")
+}
@@ -14,23 +14,50 @@ type Article struct {
 	Variants []ArticleVariant `json:"variants,omitempty"`
 }
 type ArticleVariant struct {
-	Name               string  `json:"name,omitempty"`
-	ArticleCode        string  `json:"article_code,omitempty"`
-	Description        string  `json:"description,omitempty"`
-	Ean                string  `json:"ean,omitempty"`
-	Sku                string  `json:"sku,omitempty"`
-	HsTariffCode       string  `json:"hs_tariff_code,omitempty"`
-	Height             int     `json:"height,omitempty"`
-	Depth              int     `json:"depth,omitempty"`
-	Width              int     `json:"width,omitempty"`
-	Weight             int     `json:"weight,omitempty"`
-	Expirable          bool    `json:"expirable,omitempty"`
-	CountryOfOrigin    string  `json:"country_of_origin,omitempty"`
-	UsingSerialNumbers bool    `json:"using_serial_numbers,omitempty"`
-	Value              float64 `json:"value,omitempty"`
+	Name               string     `json:"name,omitempty"`
+	ArticleCode        string     `json:"article_code,omitempty"`
+	Description        string     `json:"description,omitempty"`
+	Ean                string     `json:"ean,omitempty"`
+	Sku                string     `json:"sku,omitempty"`
+	HsTariffCode       string     `json:"hs_tariff_code,omitempty"`
+	Height             int        `json:"height,omitempty"`
+	Depth              int        `json:"depth,omitempty"`
+	Width              int        `json:"width,omitempty"`
+	Weight             int        `json:"weight,omitempty"`
+	Expirable          bool       `json:"expirable,omitempty"`
+	CountryOfOrigin    string     `json:"country_of_origin,omitempty"`
+	UsingSerialNumbers bool       `json:"using_serial_numbers,omitempty"`
+	Value              MajorUnits `json:"value,omitempty"`
+}
+
+// ArticleUpdate holds the fields to change with ArticlesService.Patch. Only
+// the fields that are set are sent.
+type ArticleUpdate struct {
+	Name     Nullable[string]                 `json:"name,omitempty"`
+	Variants Nullable[[]ArticleVariantUpdate] `json:"variants,omitempty"`
+}
+
+// ArticleVariantUpdate is a variant in an ArticleUpdate. Only the fields that
+// are set are sent, so for example Expirable can be set to false.
+type ArticleVariantUpdate struct {
+	Name               Nullable[string]     `json:"name,omitempty"`
+	ArticleCode        Nullable[string]     `json:"article_code,omitempty"`
+	Description        Nullable[string]     `json:"description,omitempty"`
+	Ean                Nullable[string]     `json:"ean,omitempty"`
+	Sku                Nullable[string]     `json:"sku,omitempty"`
+	HsTariffCode       Nullable[string]     `json:"hs_tariff_code,omitempty"`
+	Height             Nullable[int]        `json:"height,omitempty"`
+	Depth              Nullable[int]        `json:"depth,omitempty"`
+	Width              Nullable[int]        `json:"width,omitempty"`
+	Weight             Nullable[int]        `json:"weight,omitempty"`
+	Expirable          Nullable[bool]       `json:"expirable,omitempty"`
+	CountryOfOrigin    Nullable[string]     `json:"country_of_origin,omitempty"`
+	UsingSerialNumbers Nullable[bool]       `json:"using_serial_numbers,omitempty"`
+	Value              Nullable[MajorUnits] `json:"value,omitempty"`
 }
 
 type ArticleListOptions struct {
+	Page      int    `url:"page,omitempty"`
 	From      string `url:"from,omitempty"`
 	To        string `url:"to,omitempty"`
 	Limit     int    `url:"limit,omitempty"`
@@ -94,3 +121,17 @@ func (as *ArticlesService) Update(ctx context.Context, articleID string, art Art
 
 	return
 }
+
+// Patch changes the fields set in upd and leaves all other fields untouched.
+func (as *ArticlesService) Patch(ctx context.Context, articleID string, upd ArticleUpdate) (article *Article, res *Response, err error) {
+	res, err = as.client.patch(ctx, fmt.Sprintf("wms/articles/%s/", articleID), upd, nil)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &article); err != nil {
+		return
+	}
+
+	return
+}
@@ -1,8 +1,55 @@
 package ewhs
 
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+var errMalformedToken = errors.New("malformed access token")
+
 type AuthToken struct {
 	Token        string `json:"token,omitempty"`
 	Iat          int    `json:"iat,omitempty"`
 	Exp          int    `json:"exp,omitempty"`
 	RefreshToken string `json:"refresh_token,omitempty"`
 }
+
+// TokenClaims are the claims of the access token issued by the API.
+type TokenClaims struct {
+	Iat         int      `json:"iat,omitempty"`
+	Exp         int      `json:"exp,omitempty"`
+	Roles       []string `json:"roles,omitempty"`
+	Username    string   `json:"username,omitempty"`
+	UserID      string   `json:"user_id,omitempty"`
+	UserType    string   `json:"user_type,omitempty"`
+	CustomerIDs []string `json:"customer_ids,omitempty"`
+}
+
+// TokenClaims decodes the claims of the current access token, authorizing
+// first when the client has no token yet. The signature is not verified.
+func (c *Client) TokenClaims(ctx context.Context) (*TokenClaims, error) {
+	token, err := c.accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errMalformedToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, errMalformedToken
+	}
+
+	claims := &TokenClaims{}
+	if err := json.Unmarshal(payload, claims); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,177 @@
+package ewhs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+type BatchesService service
+
+// Batch is a lot of a variant with its own expiry date.
+type Batch struct {
+	ID          string   `json:"id,omitempty"`
+	LotNumber   string   `json:"lot_number,omitempty"`
+	ExpiryDate  string   `json:"expiry_date,omitempty"`
+	Quantity    int      `json:"quantity,omitempty"`
+	ArticleCode string   `json:"article_code,omitempty"`
+	Variant     *Variant `json:"variant,omitempty"`
+}
+
+// BatchStock is the stock of one article broken down by batch, with the
+// batch that expires first at the front.
+type BatchStock struct {
+	ArticleCode string
+	Quantity    int
+	Batches     []Batch
+}
+
+type BatchListOptions struct {
+	Variant     string `url:"variant,omitempty"`
+	ArticleCode string `url:"article_code,omitempty"`
+	LotNumber   string `url:"lot_number,omitempty"`
+	ExpiryFrom  string `url:"expiry_date_gte,omitempty"`
+	ExpiryTo    string `url:"expiry_date_lte,omitempty"`
+	Page        int    `url:"page,omitempty"`
+	Limit       int    `url:"limit,omitempty"`
+	Sort        string `url:"sort,omitempty"`
+	Direction   string `url:"direction,omitempty"`
+}
+
+// Expiry parses the expiry date of the batch. It reports false when the batch
+// has no (valid) expiry date.
+func (b Batch) Expiry() (time.Time, bool) {
+	for _, layout := range []string{"2006-01-02", time.RFC3339} {
+		if t, err := time.Parse(layout, b.ExpiryDate); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func (bs *BatchesService) List(ctx context.Context, opts *BatchListOptions) (list *[]Batch, res *Response, err error) {
+	res, err = bs.client.get(ctx, "wms/batches/", opts)
+	if err != nil {
+		return
+	}
+
+	if len(res.content) == 0 {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &list); err != nil {
+		return
+	}
+
+	return
+}
+
+func (bs *BatchesService) Get(ctx context.Context, batchID string) (batch *Batch, res *Response, err error) {
+	res, err = bs.client.get(ctx, fmt.Sprintf("wms/batches/%s/", batchID), nil)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &batch); err != nil {
+		return
+	}
+
+	return
+}
+
+// ListForVariant returns all batches of a variant, first expiring first.
+func (bs *BatchesService) ListForVariant(ctx context.Context, variantID string) ([]Batch, error) {
+	batches, err := bs.listAll(ctx, BatchListOptions{Variant: variantID})
+	if err != nil {
+		return nil, err
+	}
+
+	SortFEFO(batches)
+
+	return batches, nil
+}
+
+// Stock returns the batches matching opts grouped per article code, sorted by
+// article code. Empty batches are left out.
+func (bs *BatchesService) Stock(ctx context.Context, opts *BatchListOptions) ([]BatchStock, error) {
+	var o BatchListOptions
+	if opts != nil {
+		o = *opts
+	}
+
+	batches, err := bs.listAll(ctx, o)
+	if err != nil {
+		return nil, err
+	}
+
+	SortFEFO(batches)
+
+	index := map[string]int{}
+	stock := []BatchStock{}
+
+	for _, b := range batches {
+		if b.Quantity == 0 {
+			continue
+		}
+
+		code := b.ArticleCode
+		if code == "" && b.Variant != nil {
+			code = b.Variant.ArticleCode
+		}
+
+		i, ok := index[code]
+		if !ok {
+			i = len(stock)
+			index[code] = i
+			stock = append(stock, BatchStock{ArticleCode: code})
+		}
+
+		stock[i].Quantity += b.Quantity
+		stock[i].Batches = append(stock[i].Batches, b)
+	}
+
+	sort.Slice(stock, func(i, j int) bool { return stock[i].ArticleCode < stock[j].ArticleCode })
+
+	return stock, nil
+}
+
+func (bs *BatchesService) listAll(ctx context.Context, opts BatchListOptions) ([]Batch, error) {
+	return listPages(opts.Limit, func(page int, limit int) (*[]Batch, *Response, error) {
+		opts.Page = page
+		opts.Limit = limit
+
+		return bs.List(ctx, &opts)
+	})
+}
+
+// SortFEFO sorts batches first expired, first out. Batches without an expiry
+// date are placed last.
+func SortFEFO(batches []Batch) {
+	sort.SliceStable(batches, func(i, j int) bool {
+		ei, oki := batches[i].Expiry()
+		ej, okj := batches[j].Expiry()
+
+		if oki != okj {
+			return oki
+		}
+
+		return ei.Before(ej)
+	})
+}
+
+// ExpiringBefore returns the batches with stock that expire before t, for
+// example to raise alerts for batches that expire within the next 30 days.
+func ExpiringBefore(batches []Batch, t time.Time) []Batch {
+	var expiring []Batch
+
+	for _, b := range batches {
+		if e, ok := b.Expiry(); ok && b.Quantity > 0 && e.Before(t) {
+			expiring = append(expiring, b)
+		}
+	}
+
+	return expiring
+}
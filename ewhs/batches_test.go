@@ -0,0 +1,65 @@
+package ewhs
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchesService_Stock(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+
+	pages := map[string]string{
+		"1": `[
+			{"id": "b1", "lot_number": "L1", "expiry_date": "2023-06-01", "quantity": 5, "article_code": "cream"},
+			{"id": "b2", "lot_number": "L2", "expiry_date": "2023-03-01", "quantity": 2, "article_code": "cream"}
+		]`,
+		"2": `[
+			{"id": "b3", "lot_number": "L3", "quantity": 4, "variant": {"article_code": "soap"}},
+			{"id": "b4", "lot_number": "L4", "expiry_date": "2023-01-01", "quantity": 0, "article_code": "soap"}
+		]`,
+		"3": `[]`,
+	}
+
+	tMux.HandleFunc("/wms/batches/", func(w http.ResponseWriter, r *http.Request) {
+		testHeader(t, r, AuthHeader, "Bearer eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+		assert.Equal(t, "2", r.URL.Query().Get("limit"))
+		_, _ = w.Write([]byte(pages[r.URL.Query().Get("page")]))
+	})
+
+	stock, err := tClient.Batches.Stock(context.Background(), &BatchListOptions{Limit: 2})
+	assert.Nil(t, err)
+	assert.Len(t, stock, 2)
+
+	assert.Equal(t, "cream", stock[0].ArticleCode)
+	assert.Equal(t, 7, stock[0].Quantity)
+	assert.Equal(t, "L2", stock[0].Batches[0].LotNumber, "the first expiring batch comes first")
+
+	assert.Equal(t, "soap", stock[1].ArticleCode)
+	assert.Equal(t, 4, stock[1].Quantity)
+	assert.Len(t, stock[1].Batches, 1, "empty batches are left out")
+}
+
+func TestExpiringBefore(t *testing.T) {
+	batches := []Batch{
+		{LotNumber: "L1", ExpiryDate: "2023-06-01", Quantity: 5},
+		{LotNumber: "L2", ExpiryDate: "2023-03-01", Quantity: 2},
+		{LotNumber: "L3", Quantity: 4},
+		{LotNumber: "L4", ExpiryDate: "2023-01-01", Quantity: 0},
+	}
+
+	expiring := ExpiringBefore(batches, time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC))
+	assert.Len(t, expiring, 1)
+	assert.Equal(t, "L2", expiring[0].LotNumber)
+
+	SortFEFO(batches)
+	assert.Equal(t, []string{"L4", "L2", "L1", "L3"}, []string{
+		batches[0].LotNumber, batches[1].LotNumber, batches[2].LotNumber, batches[3].LotNumber,
+	})
+}
@@ -0,0 +1,209 @@
+package ewhs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+const defaultBatchConcurrency int = 4
+
+// errBatchAborted is the error of the items of a fail-fast batch that were
+// not run because an earlier item failed.
+var errBatchAborted = errors.New("batch aborted after an earlier failure")
+
+// BatchOptions configures a batch call.
+type BatchOptions struct {
+	// Concurrency is the number of calls run in parallel. Use
+	// Client.WithRateLimit to also limit the number of requests per second.
+	Concurrency int
+	// FailFast stops starting new calls after the first failure. By default
+	// every item is tried.
+	FailFast bool
+}
+
+// BatchItem is the outcome of the call for one key of a batch.
+type BatchItem[K any, T any] struct {
+	Key      K
+	Value    T
+	Response *Response
+	Err      error
+}
+
+// BatchResult holds the outcome of a batch call per key, in the order of
+// the keys.
+type BatchResult[K any, T any] struct {
+	Items []BatchItem[K, T]
+}
+
+// Values returns the values of the items that succeeded.
+func (r *BatchResult[K, T]) Values() []T {
+	var values []T
+
+	for _, item := range r.Items {
+		if item.Err == nil {
+			values = append(values, item.Value)
+		}
+	}
+
+	return values
+}
+
+// Failed returns the items that failed or were not run.
+func (r *BatchResult[K, T]) Failed() []BatchItem[K, T] {
+	var failed []BatchItem[K, T]
+
+	for _, item := range r.Items {
+		if item.Err != nil {
+			failed = append(failed, item)
+		}
+	}
+
+	return failed
+}
+
+// Err returns the errors of the failed items joined into one error, or nil
+// when all items succeeded. Items skipped by a fail-fast batch are left out.
+func (r *BatchResult[K, T]) Err() error {
+	var errs []error
+
+	for i, item := range r.Items {
+		if item.Err != nil && !errors.Is(item.Err, errBatchAborted) {
+			errs = append(errs, fmt.Errorf("%s: %w", batchKey(i, item.Key), item.Err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// batchKey names an item in errors by its key, or by its position when the
+// key is not a string or number.
+func batchKey(i int, key interface{}) string {
+	switch k := key.(type) {
+	case string:
+		return k
+	case fmt.Stringer:
+		return k.String()
+	case int, int64, uint, uint64:
+		return fmt.Sprint(k)
+	}
+
+	return fmt.Sprintf("#%d", i)
+}
+
+// RunBatch calls fn for every key with bounded concurrency and collects the
+// results. It is the building block of GetMany and CancelMany and can be used
+// for any other call, e.g.
+//
+//	res := ewhs.RunBatch(ctx, ids, nil, func(ctx context.Context, id string) (*ewhs.Order, *ewhs.Response, error) {
+//		return client.Orders.Patch(ctx, id, upd)
+//	})
+func RunBatch[K any, T any](ctx context.Context, keys []K, opts *BatchOptions, fn func(ctx context.Context, key K) (T, *Response, error)) *BatchResult[K, T] {
+	var o BatchOptions
+	if opts != nil {
+		o = *opts
+	}
+
+	concurrency := o.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	result := &BatchResult[K, T]{Items: make([]BatchItem[K, T], len(keys))}
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed bool
+	)
+
+	aborted := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return failed
+	}
+
+	sem := make(chan struct{}, concurrency)
+
+	for i, key := range keys {
+		item := &result.Items[i]
+		item.Key = key
+
+		acquired := false
+
+		select {
+		case sem <- struct{}{}:
+			acquired = true
+		case <-ctx.Done():
+		}
+
+		switch {
+		case aborted():
+			item.Err = errBatchAborted
+		case ctx.Err() != nil:
+			item.Err = ctx.Err()
+		}
+
+		if item.Err != nil {
+			if acquired {
+				<-sem
+			}
+
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(key K) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			value, res, err := fn(ctx, key)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			// calls cut short by an earlier failure did not fail themselves
+			if err != nil && failed && parent.Err() == nil && errors.Is(err, context.Canceled) {
+				err = errBatchAborted
+			}
+
+			item.Value, item.Response, item.Err = value, res, err
+
+			if err != nil && o.FailFast && !failed {
+				failed = true
+				cancel()
+			}
+		}(key)
+	}
+
+	wg.Wait()
+
+	return result
+}
+
+// GetMany gets the orders with the given IDs.
+func (os *OrdersService) GetMany(ctx context.Context, orderIDs []string, opts *BatchOptions) *BatchResult[string, *Order] {
+	return RunBatch(ctx, orderIDs, opts, os.Get)
+}
+
+// CancelMany cancels the orders with the given IDs.
+func (os *OrdersService) CancelMany(ctx context.Context, orderIDs []string, opts *BatchOptions) *BatchResult[string, struct{}] {
+	return RunBatch(ctx, orderIDs, opts, func(ctx context.Context, orderID string) (struct{}, *Response, error) {
+		res, err := os.Cancel(ctx, orderID)
+		return struct{}{}, res, err
+	})
+}
+
+// GetMany gets the articles with the given IDs.
+func (as *ArticlesService) GetMany(ctx context.Context, articleIDs []string, opts *BatchOptions) *BatchResult[string, *Article] {
+	return RunBatch(ctx, articleIDs, opts, as.Get)
+}
@@ -0,0 +1,196 @@
+package ewhs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ewarehousing-solutions/ewhs-api-go/test/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrdersService_GetMany(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+
+	tMux.HandleFunc("/wms/orders/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/wms/orders/"), "/")
+		if id == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"status":404,"title":"Not Found","detail":"Order not found"}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"id":"` + id + `"}`))
+	})
+
+	ids := []string{"a", "b", "missing", "c", "d", "e"}
+	res := tClient.Orders.GetMany(context.Background(), ids, &BatchOptions{Concurrency: 2})
+
+	assert.Len(t, res.Items, len(ids))
+	assert.LessOrEqual(t, maxInFlight, 2)
+
+	for i, item := range res.Items {
+		assert.Equal(t, ids[i], item.Key)
+
+		if item.Key == "missing" {
+			assert.NotNil(t, item.Err)
+			assert.Equal(t, http.StatusNotFound, item.Response.StatusCode)
+			continue
+		}
+
+		assert.Nil(t, item.Err)
+		assert.Equal(t, item.Key, item.Value.ID)
+	}
+
+	assert.Len(t, res.Values(), 5)
+	assert.Len(t, res.Failed(), 1)
+	assert.ErrorContains(t, res.Err(), "missing: ")
+}
+
+func TestOrdersService_GetManyWithoutToken(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	logins := 0
+
+	tMux.HandleFunc("/wms/auth/login/", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		logins++
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+		_, _ = w.Write([]byte(testdata.CreateAuthTokenResponse))
+	})
+	tMux.HandleFunc("/wms/orders/", func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasPrefix(r.Header.Get(AuthHeader), "Bearer eyJ"))
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	res := tClient.Orders.GetMany(context.Background(), []string{"a", "b", "c", "d", "e", "f", "g", "h"}, &BatchOptions{Concurrency: 8})
+	assert.Nil(t, res.Err())
+	assert.Equal(t, 1, logins, "concurrent requests share a single login")
+}
+
+func TestOrdersService_CancelMany(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        *BatchOptions
+		wantCalls   int
+		wantAborted int
+	}{
+		{"best effort tries every order", &BatchOptions{Concurrency: 1}, 4, 0},
+		{"fail fast stops after the first failure", &BatchOptions{Concurrency: 1, FailFast: true}, 2, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup()
+			defer teardown()
+
+			tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+
+			var mu sync.Mutex
+			calls := 0
+
+			tMux.HandleFunc("/wms/orders/", func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, http.MethodPatch)
+
+				mu.Lock()
+				calls++
+				mu.Unlock()
+
+				if strings.HasPrefix(r.URL.Path, "/wms/orders/shipped/") {
+					w.WriteHeader(http.StatusUnprocessableEntity)
+					_, _ = w.Write([]byte(`{"status":422,"title":"Validation Failed","detail":"Order cannot be cancelled"}`))
+				}
+			})
+
+			res := tClient.Orders.CancelMany(context.Background(), []string{"a", "shipped", "b", "c"}, tt.opts)
+
+			assert.Equal(t, tt.wantCalls, calls)
+			assert.Len(t, res.Failed(), 1+tt.wantAborted)
+
+			aborted := 0
+			for _, item := range res.Items {
+				if errors.Is(item.Err, errBatchAborted) {
+					aborted++
+				}
+			}
+
+			assert.Equal(t, tt.wantAborted, aborted)
+			assert.ErrorContains(t, res.Err(), "shipped: ")
+		})
+	}
+}
+
+func TestRunBatch_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	res := RunBatch(ctx, []int{1, 2, 3}, nil, func(ctx context.Context, key int) (int, *Response, error) {
+		t.Fatal("no call expected after the context is cancelled")
+		return 0, nil, nil
+	})
+
+	for _, item := range res.Items {
+		assert.True(t, errors.Is(item.Err, context.Canceled))
+	}
+
+	assert.NotNil(t, res.Err())
+}
+
+func TestClient_WithRateLimit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+	tMux.HandleFunc("/wms/orders/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	assert.Equal(t, errInvalidRateLimit, tClient.WithRateLimit(0, 1))
+	assert.Nil(t, tClient.WithRateLimit(50, 2))
+	assert.Equal(t, []string{MiddlewareRetry, MiddlewareRateLimit, MiddlewareLogging, MiddlewareExpand, MiddlewareAuth}, tClient.Middlewares())
+
+	start := time.Now()
+	res := tClient.Orders.GetMany(context.Background(), []string{"a", "b", "c", "d", "e", "f"}, &BatchOptions{Concurrency: 6})
+	assert.Nil(t, res.Err())
+
+	// two requests fit in the burst, the other four wait 20ms each
+	assert.GreaterOrEqual(t, time.Since(start), 70*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+
+	assert.Nil(t, tClient.WithRateLimit(0.1, 1))
+	_, _, _ = tClient.Orders.Get(context.Background(), "a")
+
+	_, _, err := tClient.Orders.Get(ctx, "a")
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
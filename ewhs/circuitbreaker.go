@@ -0,0 +1,330 @@
+package ewhs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const MiddlewareCircuitBreaker string = "circuit_breaker"
+
+// ErrCircuitOpen is matched by errors.Is for every CircuitOpenError.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitOpenError is returned without sending the request while the circuit
+// of an endpoint group is open.
+type CircuitOpenError struct {
+	Group      string
+	RetryAfter time.Duration
+}
+
+// Error interface compliance.
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit breaker is open for %s, retry after %s", e.Group, e.RetryAfter)
+}
+
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+type CircuitState int
+
+const (
+	CircuitClosed CircuitState = iota
+	CircuitOpen
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+
+	return fmt.Sprintf("CircuitState(%d)", int(s))
+}
+
+// CircuitBreakerSettings configures the circuit of an endpoint group.
+type CircuitBreakerSettings struct {
+	// FailureThreshold is the number of consecutive failures that opens the circuit.
+	FailureThreshold int
+	// OpenTimeout is how long the circuit stays open before trial requests are let through.
+	OpenTimeout time.Duration
+	// HalfOpenRequests is the number of successful trial requests that closes the circuit.
+	HalfOpenRequests int
+}
+
+var defaultCircuitBreakerSettings = CircuitBreakerSettings{
+	FailureThreshold: 5,
+	OpenTimeout:      30 * time.Second,
+	HalfOpenRequests: 1,
+}
+
+// CircuitBreakerOptions configures the circuit breaker of a Client. Transport
+// errors, 429 and 5xx responses count as failures.
+type CircuitBreakerOptions struct {
+	// Default is used for every group without its own settings.
+	Default CircuitBreakerSettings
+	// Groups overrides the settings per endpoint group, e.g. "orders" or "stock".
+	Groups map[string]CircuitBreakerSettings
+	// GroupFunc maps a request to its endpoint group. By default the resource
+	// name in the path is used, so wms/orders/{id}/ belongs to "orders".
+	GroupFunc func(req *http.Request) string
+	// OnStateChange is called after the circuit of a group changed state.
+	OnStateChange func(group string, from CircuitState, to CircuitState)
+}
+
+type circuit struct {
+	settings  CircuitBreakerSettings
+	state     CircuitState
+	failures  int
+	successes int
+	trials    int
+	openedAt  time.Time
+	// generation is incremented on every state change, so results of
+	// requests admitted in an earlier state are not counted in the new one
+	generation uint64
+}
+
+type circuitBreaker struct {
+	mu       sync.Mutex
+	opts     CircuitBreakerOptions
+	circuits map[string]*circuit
+	now      func() time.Time
+}
+
+// WithCircuitBreaker adds a circuit breaker in front of the retry middleware,
+// so requests to an unavailable endpoint group fail fast with a CircuitOpenError.
+func (c *Client) WithCircuitBreaker(opts CircuitBreakerOptions) error {
+	if opts.GroupFunc == nil {
+		opts.GroupFunc = endpointGroup
+	}
+
+	cb := &circuitBreaker{
+		opts:     opts,
+		circuits: map[string]*circuit{},
+		now:      time.Now,
+	}
+
+	c.setMiddleware(MiddlewareCircuitBreaker, cb.middleware, func() int {
+		if i := c.middlewareIndex(MiddlewareRetry); i >= 0 {
+			return i
+		}
+
+		return len(c.middlewares)
+	})
+
+	c.middlewaresMu.Lock()
+	c.breaker = cb
+	c.middlewaresMu.Unlock()
+
+	return nil
+}
+
+// CircuitState returns the state of the circuit for an endpoint group.
+func (c *Client) CircuitState(group string) CircuitState {
+	c.middlewaresMu.RLock()
+	cb := c.breaker
+	c.middlewaresMu.RUnlock()
+
+	if cb == nil {
+		return CircuitClosed
+	}
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	return cb.circuit(group).currentState(cb.now())
+}
+
+func (cb *circuitBreaker) middleware(next RoundTrip) RoundTrip {
+	return func(req *http.Request) (*Response, error) {
+		group := cb.opts.GroupFunc(req)
+
+		generation, err := cb.allow(group)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := next(req)
+		cb.record(group, generation, isCircuitFailure(req.Context(), res, err))
+
+		return res, err
+	}
+}
+
+func (cb *circuitBreaker) circuit(group string) *circuit {
+	ci, ok := cb.circuits[group]
+	if !ok {
+		settings, ok := cb.opts.Groups[group]
+		if !ok {
+			settings = cb.opts.Default
+		}
+
+		if settings.FailureThreshold <= 0 {
+			settings.FailureThreshold = defaultCircuitBreakerSettings.FailureThreshold
+		}
+
+		if settings.OpenTimeout <= 0 {
+			settings.OpenTimeout = defaultCircuitBreakerSettings.OpenTimeout
+		}
+
+		if settings.HalfOpenRequests <= 0 {
+			settings.HalfOpenRequests = defaultCircuitBreakerSettings.HalfOpenRequests
+		}
+
+		ci = &circuit{settings: settings}
+		cb.circuits[group] = ci
+	}
+
+	return ci
+}
+
+func (ci *circuit) currentState(now time.Time) CircuitState {
+	if ci.state == CircuitOpen && now.Sub(ci.openedAt) >= ci.settings.OpenTimeout {
+		return CircuitHalfOpen
+	}
+
+	return ci.state
+}
+
+// allow admits a request and returns the generation of the circuit it was
+// admitted under.
+func (cb *circuitBreaker) allow(group string) (uint64, error) {
+	cb.mu.Lock()
+
+	now := cb.now()
+	ci := cb.circuit(group)
+	from := ci.state
+
+	switch ci.currentState(now) {
+	case CircuitOpen:
+		cb.mu.Unlock()
+		return 0, &CircuitOpenError{Group: group, RetryAfter: ci.settings.OpenTimeout - now.Sub(ci.openedAt)}
+	case CircuitHalfOpen:
+		if ci.state == CircuitOpen {
+			ci.setState(CircuitHalfOpen)
+			ci.successes = 0
+			ci.trials = 0
+		}
+
+		if ci.trials >= ci.settings.HalfOpenRequests {
+			cb.mu.Unlock()
+			return 0, &CircuitOpenError{Group: group}
+		}
+
+		ci.trials++
+	}
+
+	to := ci.state
+	generation := ci.generation
+	cb.mu.Unlock()
+
+	cb.notify(group, from, to)
+
+	return generation, nil
+}
+
+// record counts the result of a request admitted under the given generation.
+// Results from an earlier generation are ignored, e.g. a slow request sent
+// while the circuit was closed does not count as a half-open trial.
+func (cb *circuitBreaker) record(group string, generation uint64, failed bool) {
+	cb.mu.Lock()
+
+	ci := cb.circuit(group)
+	from := ci.state
+
+	if generation != ci.generation {
+		cb.mu.Unlock()
+		return
+	}
+
+	switch ci.state {
+	case CircuitClosed:
+		if !failed {
+			ci.failures = 0
+			break
+		}
+
+		ci.failures++
+		if ci.failures >= ci.settings.FailureThreshold {
+			ci.open(cb.now())
+		}
+	case CircuitHalfOpen:
+		if ci.trials > 0 {
+			ci.trials--
+		}
+
+		if failed {
+			ci.open(cb.now())
+			break
+		}
+
+		ci.successes++
+		if ci.successes >= ci.settings.HalfOpenRequests {
+			ci.setState(CircuitClosed)
+			ci.failures = 0
+		}
+	}
+
+	to := ci.state
+	cb.mu.Unlock()
+
+	cb.notify(group, from, to)
+}
+
+func (ci *circuit) open(now time.Time) {
+	ci.setState(CircuitOpen)
+	ci.openedAt = now
+	ci.failures = 0
+}
+
+func (ci *circuit) setState(state CircuitState) {
+	ci.state = state
+	ci.generation++
+}
+
+func (cb *circuitBreaker) notify(group string, from CircuitState, to CircuitState) {
+	if from != to && cb.opts.OnStateChange != nil {
+		cb.opts.OnStateChange(group, from, to)
+	}
+}
+
+// isCircuitFailure reports whether the outcome of a request indicates the
+// endpoint is unavailable. Cancellations by the caller are not counted.
+func isCircuitFailure(ctx context.Context, res *Response, err error) bool {
+	status := 0
+
+	var be *BaseError
+
+	switch {
+	case res != nil:
+		status = res.StatusCode
+	case errors.As(err, &be):
+		status = be.Status
+	default:
+		return err != nil && ctx.Err() == nil
+	}
+
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// endpointGroup returns the resource name of the request path, skipping the
+// "wms" prefix.
+func endpointGroup(req *http.Request) string {
+	for _, s := range strings.Split(strings.Trim(req.URL.Path, "/"), "/") {
+		if s != "" && s != "wms" {
+			return s
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,141 @@
+package ewhs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_WithCircuitBreaker(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+
+	type change struct {
+		group    string
+		from, to CircuitState
+	}
+
+	var changes []change
+
+	err := tClient.WithCircuitBreaker(CircuitBreakerOptions{
+		Default: CircuitBreakerSettings{FailureThreshold: 2, OpenTimeout: time.Minute},
+		OnStateChange: func(group string, from CircuitState, to CircuitState) {
+			changes = append(changes, change{group, from, to})
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{MiddlewareCircuitBreaker, MiddlewareRetry, MiddlewareLogging, MiddlewareExpand, MiddlewareAuth}, tClient.Middlewares())
+
+	now := time.Now()
+	tClient.breaker.now = func() time.Time { return now }
+
+	status := http.StatusInternalServerError
+	hits := 0
+	tMux.HandleFunc("/wms/orders/", func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`[]`))
+	})
+	tMux.HandleFunc("/wms/stock/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	})
+
+	for i := 0; i < 2; i++ {
+		_, _, err = tClient.Orders.List(context.Background(), nil)
+		assert.NotNil(t, err)
+		assert.False(t, errors.Is(err, ErrCircuitOpen))
+	}
+
+	assert.Equal(t, CircuitOpen, tClient.CircuitState("orders"))
+
+	_, _, err = tClient.Orders.List(context.Background(), nil)
+	assert.True(t, errors.Is(err, ErrCircuitOpen))
+
+	var coe *CircuitOpenError
+	assert.True(t, errors.As(err, &coe))
+	assert.Equal(t, "orders", coe.Group)
+	assert.Equal(t, time.Minute, coe.RetryAfter)
+	assert.Equal(t, 2, hits)
+
+	// other endpoint groups are not affected
+	_, _, err = tClient.Stock.List(context.Background(), nil)
+	assert.Nil(t, err)
+
+	// a successful trial request closes the circuit again
+	now = now.Add(time.Minute)
+	status = http.StatusOK
+	assert.Equal(t, CircuitHalfOpen, tClient.CircuitState("orders"))
+
+	_, _, err = tClient.Orders.List(context.Background(), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, CircuitClosed, tClient.CircuitState("orders"))
+
+	assert.Equal(t, []change{
+		{"orders", CircuitClosed, CircuitOpen},
+		{"orders", CircuitOpen, CircuitHalfOpen},
+		{"orders", CircuitHalfOpen, CircuitClosed},
+	}, changes)
+}
+
+func TestCircuitBreaker_HalfOpenFailure(t *testing.T) {
+	now := time.Now()
+	cb := &circuitBreaker{
+		opts:     CircuitBreakerOptions{Default: CircuitBreakerSettings{FailureThreshold: 1, OpenTimeout: time.Second}},
+		circuits: map[string]*circuit{},
+		now:      func() time.Time { return now },
+	}
+
+	gen, err := cb.allow("orders")
+	assert.Nil(t, err)
+	cb.record("orders", gen, true)
+
+	_, err = cb.allow("orders")
+	assert.True(t, errors.Is(err, ErrCircuitOpen))
+
+	now = now.Add(time.Second)
+	gen, err = cb.allow("orders")
+	assert.Nil(t, err)
+
+	_, err = cb.allow("orders")
+	assert.True(t, errors.Is(err, ErrCircuitOpen), "only one trial request is let through")
+
+	cb.record("orders", gen, true)
+	assert.Equal(t, CircuitOpen, cb.circuit("orders").currentState(now))
+}
+
+func TestCircuitBreaker_StaleResult(t *testing.T) {
+	now := time.Now()
+	cb := &circuitBreaker{
+		opts:     CircuitBreakerOptions{Default: CircuitBreakerSettings{FailureThreshold: 1, OpenTimeout: time.Second}},
+		circuits: map[string]*circuit{},
+		now:      func() time.Time { return now },
+	}
+
+	// a slow request is admitted while the circuit is closed
+	slow, err := cb.allow("orders")
+	assert.Nil(t, err)
+
+	failing, err := cb.allow("orders")
+	assert.Nil(t, err)
+	cb.record("orders", failing, true)
+
+	now = now.Add(time.Second)
+	trial, err := cb.allow("orders")
+	assert.Nil(t, err)
+
+	// the slow request finishes during the half-open trial and is ignored
+	cb.record("orders", slow, false)
+	assert.Equal(t, CircuitHalfOpen, cb.circuit("orders").currentState(now))
+
+	_, err = cb.allow("orders")
+	assert.True(t, errors.Is(err, ErrCircuitOpen), "the trial is still in flight")
+
+	cb.record("orders", trial, false)
+	assert.Equal(t, CircuitClosed, cb.circuit("orders").currentState(now))
+}
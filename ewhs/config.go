@@ -1,11 +1,22 @@
 package ewhs
 
+import "time"
+
 type Config struct {
 	Username     string
 	Password     string
 	WmsCode      string
 	CustomerCode string
 	Testing      bool
+
+	// MaxRetries is the number of times an idempotent request is retried
+	// after a transport error or a temporary server error. Zero disables retries.
+	MaxRetries int
+	// RetryWait is the initial backoff between retries, doubled on every attempt.
+	RetryWait time.Duration
+	// IdempotencyKeys enables the Idempotency-Key header on create calls, for
+	// APIs that deduplicate them. Such calls are then retried like idempotent ones.
+	IdempotencyKeys bool
 }
 
 func NewConfig(username string, password string, wmsCode string, customerCode string, testing bool) *Config {
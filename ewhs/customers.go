@@ -0,0 +1,118 @@
+package ewhs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+type CustomersService service
+
+type Customer struct {
+	ID       string                 `json:"id,omitempty"`
+	Code     string                 `json:"code,omitempty"`
+	Name     string                 `json:"name,omitempty"`
+	Settings map[string]interface{} `json:"settings,omitempty"`
+}
+
+type CustomerListOptions struct {
+	Search    string `url:"search,omitempty"`
+	Page      int    `url:"page,omitempty"`
+	Limit     int    `url:"limit,omitempty"`
+	Sort      string `url:"sort,omitempty"`
+	Direction string `url:"direction,omitempty"`
+}
+
+func (cs *CustomersService) List(ctx context.Context, opts *CustomerListOptions) (list *[]Customer, res *Response, err error) {
+	res, err = cs.client.get(ctx, "wms/customers/", opts)
+	if err != nil {
+		return
+	}
+
+	if len(res.content) == 0 {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &list); err != nil {
+		return
+	}
+
+	return
+}
+
+func (cs *CustomersService) Get(ctx context.Context, customerID string) (customer *Customer, res *Response, err error) {
+	res, err = cs.client.get(ctx, fmt.Sprintf("wms/customers/%s/", customerID), nil)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &customer); err != nil {
+		return
+	}
+
+	return
+}
+
+// CodeFor returns the customer code of a customer ID, as used in the
+// X-Customer-Code header. Resolved codes are cached on the client.
+func (cs *CustomersService) CodeFor(ctx context.Context, customerID string) (string, error) {
+	cs.client.customersMu.Lock()
+	code, ok := cs.client.customerCodes[customerID]
+	cs.client.customersMu.Unlock()
+
+	if ok {
+		return code, nil
+	}
+
+	customer, _, err := cs.Get(ctx, customerID)
+	if err != nil {
+		return "", err
+	}
+
+	cs.cacheCode(customer.ID, customer.Code)
+
+	return customer.Code, nil
+}
+
+// Codes returns the customer code for each customer ID in the access token.
+func (cs *CustomersService) Codes(ctx context.Context) (map[string]string, error) {
+	claims, err := cs.client.TokenClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make(map[string]string, len(claims.CustomerIDs))
+
+	for _, id := range claims.CustomerIDs {
+		code, err := cs.CodeFor(ctx, id)
+		if err != nil {
+			return codes, err
+		}
+
+		codes[id] = code
+	}
+
+	return codes, nil
+}
+
+// WithCustomer returns a context for requests on behalf of the given customer
+// ID, resolving its customer code.
+func (cs *CustomersService) WithCustomer(ctx context.Context, customerID string) (context.Context, error) {
+	code, err := cs.CodeFor(ctx, customerID)
+	if err != nil {
+		return ctx, err
+	}
+
+	return WithCustomerCode(ctx, code), nil
+}
+
+func (cs *CustomersService) cacheCode(customerID string, code string) {
+	cs.client.customersMu.Lock()
+	defer cs.client.customersMu.Unlock()
+
+	if cs.client.customerCodes == nil {
+		cs.client.customerCodes = map[string]string{}
+	}
+
+	cs.client.customerCodes[customerID] = code
+}
@@ -0,0 +1,63 @@
+package ewhs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ewarehousing-solutions/ewhs-api-go/test/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_TokenClaims(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tMux.HandleFunc("/wms/auth/login/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testdata.CreateAuthTokenResponse))
+	})
+
+	claims, err := tClient.TokenClaims(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "api", claims.UserType)
+	assert.Len(t, claims.CustomerIDs, 12)
+	assert.Contains(t, claims.Roles, "ROLE_MIDDLEWARE_CUSTOMERS_READ")
+
+	tClient.WithAuthToken("not-a-jwt")
+	_, err = tClient.TokenClaims(context.Background())
+	assert.Equal(t, errMalformedToken, err)
+}
+
+func TestCustomersService_Codes(t *testing.T) {
+	setup()
+	defer teardown()
+
+	gets := 0
+
+	tMux.HandleFunc("/wms/auth/login/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testdata.CreateAuthTokenResponse))
+	})
+	tMux.HandleFunc("/wms/customers/", func(w http.ResponseWriter, r *http.Request) {
+		gets++
+		id := strings.Split(strings.Trim(r.URL.Path, "/"), "/")[2]
+		_, _ = fmt.Fprintf(w, `{"id": %q, "code": "code-%s", "name": "Customer %s"}`, id, id[:8], id[:8])
+	})
+	tMux.HandleFunc("/wms/orders/", func(w http.ResponseWriter, r *http.Request) {
+		testHeader(t, r, CustomerCodeHeader, "code-be62c27e")
+		_, _ = w.Write([]byte(`[]`))
+	})
+
+	codes, err := tClient.Customers.Codes(context.Background())
+	assert.Nil(t, err)
+	assert.Len(t, codes, 12)
+	assert.Equal(t, "code-be62c27e", codes["be62c27e-2aac-4ac1-902e-f770d64f8dce"])
+
+	ctx, err := tClient.Customers.WithCustomer(context.Background(), "be62c27e-2aac-4ac1-902e-f770d64f8dce")
+	assert.Nil(t, err)
+	assert.Equal(t, 12, gets, "resolved codes are cached")
+
+	_, _, err = tClient.Orders.List(ctx, nil)
+	assert.Nil(t, err)
+}
@@ -5,9 +5,17 @@ import "fmt"
 // BaseError contains the general error structure
 // returned by mollie.
 type BaseError struct {
-	Status int    `json:"status,omitempty"`
-	Title  string `json:"title,omitempty"`
-	Detail string `json:"detail,omitempty"`
+	Status     int         `json:"status,omitempty"`
+	Title      string      `json:"title,omitempty"`
+	Detail     string      `json:"detail,omitempty"`
+	Violations []Violation `json:"violations,omitempty"`
+}
+
+// Violation is a single invalid field of a validation error.
+type Violation struct {
+	PropertyPath string `json:"propertyPath,omitempty"`
+	Message      string `json:"message,omitempty"`
+	Code         string `json:"code,omitempty"`
 }
 
 // Error interface compliance.
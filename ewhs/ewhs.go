@@ -7,17 +7,19 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/go-querystring/query"
 )
 
 const (
-	Version     string = "1.0.5"
+	Version     string = "1.1.0"
 	BaseURL     string = "https://eu.middleware.ewarehousing-solutions.com/"
 	TestBaseURL string = "https://eu-dev.middleware.ewarehousing-solutions.com/"
 
@@ -44,12 +46,33 @@ type Client struct {
 	common    service
 	config    *Config
 
+	// authMu guards authToken; loginMu makes concurrent requests without a
+	// token wait for a single login
+	authMu    sync.RWMutex
+	loginMu   sync.Mutex
 	authToken string
 
+	logger      *slog.Logger
+	logOptions  LogOptions
+	middlewares []namedMiddleware
+	breaker     *circuitBreaker
+	limiter     *rateLimiter
+
+	// middlewaresMu guards middlewares, breaker and limiter, which may change
+	// while requests are sent
+	middlewaresMu sync.RWMutex
+
+	customersMu   sync.Mutex
+	customerCodes map[string]string
+
 	// Services
 	Articles        *ArticlesService
+	Batches         *BatchesService
+	Customers       *CustomersService
+	Exports         *ExportsService
 	Gdpr            *GdprService
 	Inbounds        *InboundsService
+	Modifications   *ModificationsService
 	Orders          *OrdersService
 	Stock           *StockService
 	Shipments       *ShipmentsService
@@ -129,7 +152,7 @@ func (c *Client) authorize(ctx context.Context) (res *Response, err error) {
 		return nil, errMissingCredentials
 	}
 
-	req, err := c.NewRequest(ctx, http.MethodPost, "wms/auth/login/", Auth{
+	req, err := c.NewRequest(ctx, http.MethodPost, loginPath, Auth{
 		Username: c.config.Username,
 		Password: c.config.Password,
 	})
@@ -158,17 +181,61 @@ func (c *Client) authorize(ctx context.Context) (res *Response, err error) {
 		return
 	}
 
-	c.authToken = authToken.Token
+	c.setAuthToken(authToken.Token)
 
 	return res, nil
 }
 
+// accessToken returns the access token, authorizing first when the client has
+// none yet. Concurrent callers share a single login.
+func (c *Client) accessToken(ctx context.Context) (string, error) {
+	if token := c.currentAuthToken(); token != "" {
+		return token, nil
+	}
+
+	c.loginMu.Lock()
+	defer c.loginMu.Unlock()
+
+	// another caller may have logged in while this one was waiting
+	if token := c.currentAuthToken(); token != "" {
+		return token, nil
+	}
+
+	if _, err := c.authorize(ctx); err != nil {
+		return "", err
+	}
+
+	return c.currentAuthToken(), nil
+}
+
+func (c *Client) currentAuthToken() string {
+	c.authMu.RLock()
+	defer c.authMu.RUnlock()
+
+	return c.authToken
+}
+
+func (c *Client) setAuthToken(token string) {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	c.authToken = token
+}
+
+type customerCodeKey struct{}
+
+// WithCustomerCode returns a context for requests on behalf of another
+// customer than the one configured on the client.
+func WithCustomerCode(ctx context.Context, code string) context.Context {
+	return context.WithValue(ctx, customerCodeKey{}, code)
+}
+
 func (c *Client) WithAuthToken(k string) error {
 	if k == "" {
 		return errEmptyAuthKey
 	}
 
-	c.authToken = strings.TrimSpace(k)
+	c.setAuthToken(strings.TrimSpace(k))
 
 	return nil
 }
@@ -227,26 +294,25 @@ func (c *Client) NewRequest(ctx context.Context, method string, uri string, body
 	req.Header.Set(CustomerCodeHeader, c.config.CustomerCode)
 	req.Header.Set(WmsCodeHeader, c.config.WmsCode)
 
-	if expand := ctx.Value("Expand"); expand != nil {
-		req.Header.Set("Expand", expand.(string))
+	if code, ok := ctx.Value(customerCodeKey{}).(string); ok && code != "" {
+		req.Header.Set(CustomerCodeHeader, code)
 	}
 
-	// if no auth token is found -> authorize first
-	if c.authToken == "" && uri != "wms/auth/login/" {
-		_, err := c.authorize(ctx)
-		if err != nil {
-			return nil, err
-		}
+	if key, ok := idempotencyKeyFromContext(ctx); ok {
+		req.Header.Set(IdempotencyKeyHeader, key)
 	}
 
-	req.Header.Add(AuthHeader, strings.Join([]string{"Bearer", c.authToken}, " "))
-
 	return req, nil
 }
 
-// Do sends an API request and returns the API response or returned as an
-// error if an API error has occurred.
+// Do sends an API request through the middleware chain and returns the API
+// response or returned as an error if an API error has occurred.
 func (c *Client) Do(req *http.Request) (*Response, error) {
+	return c.chain(c.send)(req)
+}
+
+// send performs the http request without any middleware.
+func (c *Client) send(req *http.Request) (*Response, error) {
 	resp, err := c.client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("httperror: %w", err)
@@ -290,11 +356,16 @@ func NewClient(baseClient *http.Client, c *Config) (ewhs *Client, err error) {
 	}
 
 	ewhs.common.client = ewhs
+	ewhs.middlewares = ewhs.defaultMiddlewares()
 
 	// services for resources
 	ewhs.Articles = (*ArticlesService)(&ewhs.common)
+	ewhs.Batches = (*BatchesService)(&ewhs.common)
+	ewhs.Customers = (*CustomersService)(&ewhs.common)
+	ewhs.Exports = (*ExportsService)(&ewhs.common)
 	ewhs.Gdpr = (*GdprService)(&ewhs.common)
 	ewhs.Inbounds = (*InboundsService)(&ewhs.common)
+	ewhs.Modifications = (*ModificationsService)(&ewhs.common)
 	ewhs.Orders = (*OrdersService)(&ewhs.common)
 	ewhs.Stock = (*StockService)(&ewhs.common)
 	ewhs.Shipments = (*ShipmentsService)(&ewhs.common)
@@ -339,6 +410,13 @@ func newError(rsp *Response) error {
 	merr.Detail = string(rsp.content)
 	//}
 
+	var body struct {
+		Violations []Violation `json:"violations"`
+	}
+	if json.Unmarshal(rsp.content, &body) == nil {
+		merr.Violations = body.Violations
+	}
+
 	return merr
 }
 
@@ -369,3 +447,36 @@ func CheckResponse(r *Response) error {
 	}
 	return nil
 }
+
+// stream sends a GET request through the middleware chain and copies a
+// successful response body to w instead of buffering it.
+func (c *Client) stream(ctx context.Context, uri string, w io.Writer) (*Response, error) {
+	req, err := c.NewRequest(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "*/*")
+
+	return c.chain(func(req *http.Request) (*Response, error) {
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("httperror: %w", err)
+		}
+
+		defer resp.Body.Close()
+
+		if resp.StatusCode >= http.StatusMultipleChoices {
+			response, err := newResponse(resp)
+			if err != nil {
+				return response, err
+			}
+
+			return response, CheckResponse(response)
+		}
+
+		_, err = io.Copy(w, resp.Body)
+
+		return &Response{Response: resp}, err
+	})(req)
+}
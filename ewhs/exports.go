@@ -0,0 +1,166 @@
+package ewhs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+type ExportsService service
+
+const defaultExportPollInterval = 2 * time.Second
+
+var ErrExportFailed = errors.New("export failed")
+
+type ExportType string
+
+const (
+	ExportTypeBilling   ExportType = "billing"
+	ExportTypeFinancial ExportType = "financial"
+	ExportTypeStock     ExportType = "stock"
+)
+
+type ExportStatus string
+
+const (
+	ExportStatusPending    ExportStatus = "pending"
+	ExportStatusProcessing ExportStatus = "processing"
+	ExportStatusReady      ExportStatus = "ready"
+	ExportStatusFailed     ExportStatus = "failed"
+)
+
+// Export is a report generated by the API for a date range. Dates are
+// formatted as YYYY-MM-DD.
+type Export struct {
+	ID          string       `json:"id,omitempty"`
+	Type        ExportType   `json:"type,omitempty"`
+	Status      ExportStatus `json:"status,omitempty"`
+	From        string       `json:"from,omitempty"`
+	To          string       `json:"to,omitempty"`
+	FileName    string       `json:"file_name,omitempty"`
+	ContentType string       `json:"content_type,omitempty"`
+	CreatedAt   *time.Time   `json:"created_at,omitempty"`
+	CompletedAt *time.Time   `json:"completed_at,omitempty"`
+}
+
+type ExportListOptions struct {
+	Type      ExportType   `url:"type,omitempty"`
+	Status    ExportStatus `url:"status,omitempty"`
+	Page      int          `url:"page,omitempty"`
+	From      string       `url:"from,omitempty"`
+	To        string       `url:"to,omitempty"`
+	Limit     int          `url:"limit,omitempty"`
+	Direction string       `url:"direction,omitempty"`
+}
+
+// NewExport returns an export request of the given type for the date range.
+func NewExport(exportType ExportType, from time.Time, to time.Time) Export {
+	return Export{
+		Type: exportType,
+		From: from.Format("2006-01-02"),
+		To:   to.Format("2006-01-02"),
+	}
+}
+
+func (es *ExportsService) List(ctx context.Context, opts *ExportListOptions) (list *[]Export, res *Response, err error) {
+	res, err = es.client.get(ctx, "wms/exports/", opts)
+	if err != nil {
+		return
+	}
+
+	if len(res.content) == 0 {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &list); err != nil {
+		return
+	}
+
+	return
+}
+
+func (es *ExportsService) Get(ctx context.Context, exportID string) (export *Export, res *Response, err error) {
+	res, err = es.client.get(ctx, fmt.Sprintf("wms/exports/%s/", exportID), nil)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &export); err != nil {
+		return
+	}
+
+	return
+}
+
+func (es *ExportsService) Create(ctx context.Context, exp Export) (export *Export, res *Response, err error) {
+	res, err = es.client.post(ctx, "wms/exports/", exp, nil)
+
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &export); err != nil {
+		return
+	}
+
+	return
+}
+
+// Download streams the file of a ready export to w.
+func (es *ExportsService) Download(ctx context.Context, exportID string, w io.Writer) (res *Response, err error) {
+	return es.client.stream(ctx, fmt.Sprintf("wms/exports/%s/download/", exportID), w)
+}
+
+// Wait polls the export every interval until it is ready. It returns
+// ErrExportFailed when the export failed, or the context error when ctx is
+// done first.
+func (es *ExportsService) Wait(ctx context.Context, exportID string, interval time.Duration) (export *Export, err error) {
+	if interval <= 0 {
+		interval = defaultExportPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		export, _, err = es.Get(ctx, exportID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch export.Status {
+		case ExportStatusReady:
+			return export, nil
+		case ExportStatusFailed:
+			return export, ErrExportFailed
+		}
+
+		select {
+		case <-ctx.Done():
+			return export, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// CreateAndWait requests an export, waits until it is ready and streams it to
+// w. Use a context with a deadline to bound the time spent waiting.
+func (es *ExportsService) CreateAndWait(ctx context.Context, exp Export, interval time.Duration, w io.Writer) (export *Export, err error) {
+	export, _, err = es.Create(ctx, exp)
+	if err != nil {
+		return
+	}
+
+	if export.Status != ExportStatusReady {
+		if export, err = es.Wait(ctx, export.ID, interval); err != nil {
+			return
+		}
+	}
+
+	_, err = es.Download(ctx, export.ID, w)
+
+	return
+}
@@ -0,0 +1,70 @@
+package ewhs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportsService_CreateAndWait(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+
+	polls := 0
+
+	tMux.HandleFunc("/wms/exports/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		var exp Export
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&exp))
+		assert.Equal(t, Export{Type: ExportTypeBilling, From: "2022-01-01", To: "2022-01-31"}, exp)
+
+		_, _ = w.Write([]byte(`{"id": "e1", "type": "billing", "status": "pending"}`))
+	})
+	tMux.HandleFunc("/wms/exports/e1/", func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		if polls < 3 {
+			_, _ = w.Write([]byte(`{"id": "e1", "type": "billing", "status": "processing"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": "e1", "type": "billing", "status": "ready"}`))
+	})
+	tMux.HandleFunc("/wms/exports/e1/download/", func(w http.ResponseWriter, r *http.Request) {
+		testHeader(t, r, "Accept", "*/*")
+		w.Header().Set("Content-Type", "text/csv")
+		_, _ = w.Write([]byte("order,amount\nORD1,100\n"))
+	})
+
+	exp := NewExport(ExportTypeBilling, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 1, 31, 0, 0, 0, 0, time.UTC))
+
+	var buf bytes.Buffer
+	export, err := tClient.Exports.CreateAndWait(context.Background(), exp, time.Millisecond, &buf)
+	assert.Nil(t, err)
+	assert.Equal(t, ExportStatusReady, export.Status)
+	assert.Equal(t, 3, polls)
+	assert.Equal(t, "order,amount\nORD1,100\n", buf.String())
+}
+
+func TestExportsService_WaitDeadline(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+
+	tMux.HandleFunc("/wms/exports/e1/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": "e1", "status": "processing"}`))
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := tClient.Exports.Wait(ctx, "e1", 5*time.Millisecond)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,106 @@
+package ewhs
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const IdempotencyKeyHeader string = "Idempotency-Key"
+
+type idempotencyKey struct{}
+
+// WithIdempotencyKey sets the idempotency key sent with requests made with the
+// returned context. Create calls generate a key when none is set and
+// Config.IdempotencyKeys is enabled.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKey{}, key)
+}
+
+func idempotencyKeyFromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(idempotencyKey{}).(string)
+	return key, ok && key != ""
+}
+
+// newIdempotencyKey returns a random version 4 UUID.
+func newIdempotencyKey() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// createWithGuard posts a new resource. When the API honours idempotency keys
+// a key is attached and retries are left to the retry middleware. Otherwise an
+// ambiguous failure is resolved by calling lookup, which reports whether the
+// resource was created after all, before the create is sent again with the
+// backoff of the retry middleware. When the lookup fails, or there is no
+// lookup, the create is not sent again.
+func (c *Client) createWithGuard(ctx context.Context, uri string, body interface{}, lookup func(ctx context.Context) (bool, *Response, error)) (res *Response, found bool, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if c.config != nil && c.config.IdempotencyKeys {
+		if _, ok := idempotencyKeyFromContext(ctx); !ok {
+			ctx = WithIdempotencyKey(ctx, newIdempotencyKey())
+		}
+
+		res, err = c.post(ctx, uri, body, nil)
+
+		return res, false, err
+	}
+
+	retries := 0
+	if c.config != nil {
+		retries = c.config.MaxRetries
+	}
+
+	for attempt := 0; ; attempt++ {
+		res, err = c.post(ctx, uri, body, nil)
+		if err == nil || lookup == nil || !isAmbiguous(ctx, res, err) {
+			return res, false, err
+		}
+
+		ok, lookupRes, lookupErr := lookup(ctx)
+		if lookupErr != nil {
+			// without a lookup it is unknown whether the resource was
+			// created, so it is not sent again
+			return res, false, fmt.Errorf("%w; looking up the created resource: %w", err, lookupErr)
+		}
+
+		if ok {
+			return lookupRes, true, nil
+		}
+
+		if attempt >= retries || !sleep(ctx, retryDelay(res, c.retryWait(), attempt+1)) {
+			return res, false, err
+		}
+	}
+}
+
+// isAmbiguous reports whether a failed request may still have been processed
+// by the server.
+func isAmbiguous(ctx context.Context, res *Response, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+
+	if res == nil {
+		var ue *url.Error
+		return errors.As(err, &ue)
+	}
+
+	switch res.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
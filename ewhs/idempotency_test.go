@@ -0,0 +1,154 @@
+package ewhs
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ewarehousing-solutions/ewhs-api-go/test/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrdersService_CreateGuard(t *testing.T) {
+	tests := []struct {
+		name      string
+		existing  string
+		wantPosts int
+		wantErr   bool
+	}{
+		{
+			"an order committed before the connection dropped is returned",
+			`[{"id":"c9165f93-8301-4aaa-9f64-27f191c0c778","external_reference":"1644571933"}]`,
+			1,
+			false,
+		},
+		{
+			"an order that was not committed is created again",
+			`[]`,
+			2,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup()
+			defer teardown()
+
+			tConf.MaxRetries = 1
+			tConf.RetryWait = time.Millisecond
+			tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+
+			posts := 0
+			tMux.HandleFunc("/wms/orders/", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					testQuery(t, r, "external_reference=1644571933")
+					_, _ = w.Write([]byte(tt.existing))
+					return
+				}
+
+				testHeader(t, r, IdempotencyKeyHeader, "")
+				posts++
+				panic(http.ErrAbortHandler)
+			})
+
+			order, _, err := tClient.Orders.Create(context.Background(), Order{ExternalReference: "1644571933"})
+			assert.Equal(t, tt.wantPosts, posts)
+
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				assert.Nil(t, order)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, "c9165f93-8301-4aaa-9f64-27f191c0c778", order.ID)
+			}
+		})
+	}
+}
+
+func TestOrdersService_CreateGuardLookupFails(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tConf.MaxRetries = 1
+	tConf.RetryWait = time.Millisecond
+	tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+
+	posts := 0
+	tMux.HandleFunc("/wms/orders/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		posts++
+		panic(http.ErrAbortHandler)
+	})
+
+	order, _, err := tClient.Orders.Create(context.Background(), Order{ExternalReference: "1644571933"})
+
+	assert.Nil(t, order)
+	assert.ErrorContains(t, err, "looking up the created resource")
+	assert.Equal(t, 1, posts, "the create is not sent again when it is unknown whether it was committed")
+}
+
+func TestOrdersService_CreateGuardBackoff(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tConf.MaxRetries = 5
+	tConf.RetryWait = time.Hour
+	tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+
+	posts := 0
+	tMux.HandleFunc("/wms/orders/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+
+		posts++
+		panic(http.ErrAbortHandler)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, _, err := tClient.Orders.Create(ctx, Order{ExternalReference: "1644571933"})
+
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, posts, "the create is not sent again before the backoff has passed")
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestOrdersService_CreateIdempotencyKey(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tConf.IdempotencyKeys = true
+	tConf.MaxRetries = 2
+	tConf.RetryWait = time.Millisecond
+	tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+
+	keys := []string{}
+	tMux.HandleFunc("/wms/orders/", func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+
+		if len(keys) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		_, _ = w.Write([]byte(testdata.GetOrderResponse))
+	})
+
+	order, _, err := tClient.Orders.Create(context.Background(), Order{ExternalReference: "1644571933"})
+	assert.Nil(t, err)
+	assert.Equal(t, "c9165f93-8301-4aaa-9f64-27f191c0c778", order.ID)
+
+	assert.Len(t, keys, 2)
+	assert.Len(t, keys[0], 36)
+	assert.Equal(t, keys[0], keys[1], "a retried create reuses its idempotency key")
+}
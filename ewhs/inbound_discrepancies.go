@@ -0,0 +1,253 @@
+package ewhs
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+type DiscrepancyKind string
+
+const (
+	// DiscrepancyShort means less was received than announced.
+	DiscrepancyShort DiscrepancyKind = "short"
+	// DiscrepancyOver means more was received than announced.
+	DiscrepancyOver DiscrepancyKind = "over"
+	// DiscrepancyUnexpected means an article was received that was not announced.
+	DiscrepancyUnexpected DiscrepancyKind = "unexpected"
+)
+
+// InboundDiscrepancy is the difference between the announced and the received
+// quantity of an article. In an aggregated report InboundID, Reference and
+// ExternalReference are empty.
+type InboundDiscrepancy struct {
+	InboundID         string
+	Reference         string
+	ExternalReference string
+	ArticleCode       string
+	Kind              DiscrepancyKind
+	Expected          int
+	Received          int
+}
+
+// Difference returns the received minus the expected quantity, so shortages
+// are negative.
+func (d InboundDiscrepancy) Difference() int {
+	return d.Received - d.Expected
+}
+
+// DiscrepancyReport holds the discrepancies of one or more inbounds, sorted by
+// inbound and article code.
+type DiscrepancyReport struct {
+	Inbounds      []string
+	Discrepancies []InboundDiscrepancy
+}
+
+// Kind returns the discrepancies of the given kind.
+func (r *DiscrepancyReport) Kind(kind DiscrepancyKind) []InboundDiscrepancy {
+	var list []InboundDiscrepancy
+
+	for _, d := range r.Discrepancies {
+		if d.Kind == kind {
+			list = append(list, d)
+		}
+	}
+
+	return list
+}
+
+// DiscrepancyReportOptions selects the inbounds of a report by inbound date.
+type DiscrepancyReportOptions struct {
+	From time.Time
+	To   time.Time
+	// Statuses are the statuses of the inbounds to include. Only completed
+	// inbounds are included by default, as the received quantities of other
+	// inbounds are not final.
+	Statuses []InboundStatus
+	// Aggregate sums the shortages, overages and unexpected articles per
+	// article code across all inbounds instead of reporting every inbound
+	// separately. Shortages and overages are summed apart, so a shortage in
+	// one inbound does not cancel out an overage in another.
+	Aggregate bool
+}
+
+func (opts DiscrepancyReportOptions) includes(status InboundStatus) bool {
+	if len(opts.Statuses) == 0 {
+		return status == InboundStatusCompleted
+	}
+
+	for _, s := range opts.Statuses {
+		if s == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+type articleQuantities struct {
+	expected int
+	received int
+}
+
+// Discrepancies compares the announced with the received quantities of an
+// inbound per article code. Articles received in full are left out.
+func Discrepancies(inbound *Inbound) []InboundDiscrepancy {
+	if inbound == nil {
+		return nil
+	}
+
+	list := discrepancies(inboundQuantities(inbound))
+	for i := range list {
+		list[i].InboundID = inbound.ID
+		list[i].Reference = inbound.Reference
+		list[i].ExternalReference = inbound.ExternalReference
+	}
+
+	return list
+}
+
+// DiscrepancyReport returns the discrepancies of a single inbound.
+func (is *InboundsService) DiscrepancyReport(ctx context.Context, inboundID string) (*DiscrepancyReport, error) {
+	inbound, _, err := is.Get(ctx, inboundID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DiscrepancyReport{
+		Inbounds:      []string{inbound.ID},
+		Discrepancies: Discrepancies(inbound),
+	}, nil
+}
+
+// DiscrepancyReportForRange returns the discrepancies of all inbounds with an
+// inbound date within the range of opts and one of its statuses.
+func (is *InboundsService) DiscrepancyReportForRange(ctx context.Context, opts DiscrepancyReportOptions) (*DiscrepancyReport, error) {
+	lo := InboundListOptions{}
+	if !opts.From.IsZero() {
+		lo.From = opts.From.Format("2006-01-02")
+	}
+
+	if !opts.To.IsZero() {
+		lo.To = opts.To.Format("2006-01-02")
+	}
+
+	inbounds, err := listPages(0, func(page int, limit int) (*[]Inbound, *Response, error) {
+		lo.Page = page
+		lo.Limit = limit
+
+		return is.List(ctx, &lo)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &DiscrepancyReport{}
+
+	for i := range inbounds {
+		inbound := &inbounds[i]
+		if !opts.includes(inbound.Status) {
+			continue
+		}
+
+		// the list endpoint may leave out the lines of an inbound
+		if inbound.InboundLines == nil {
+			if inbound, _, err = is.Get(ctx, inbound.ID); err != nil {
+				return nil, err
+			}
+		}
+
+		report.Inbounds = append(report.Inbounds, inbound.ID)
+		report.Discrepancies = append(report.Discrepancies, Discrepancies(inbound)...)
+	}
+
+	if opts.Aggregate {
+		report.Discrepancies = aggregateDiscrepancies(report.Discrepancies)
+	}
+
+	return report, nil
+}
+
+// aggregateDiscrepancies sums the discrepancies per article code and kind.
+func aggregateDiscrepancies(list []InboundDiscrepancy) []InboundDiscrepancy {
+	type key struct {
+		code string
+		kind DiscrepancyKind
+	}
+
+	var sums []InboundDiscrepancy
+	index := map[key]int{}
+
+	for _, d := range list {
+		k := key{d.ArticleCode, d.Kind}
+
+		i, ok := index[k]
+		if !ok {
+			i = len(sums)
+			index[k] = i
+			sums = append(sums, InboundDiscrepancy{ArticleCode: d.ArticleCode, Kind: d.Kind})
+		}
+
+		sums[i].Expected += d.Expected
+		sums[i].Received += d.Received
+	}
+
+	sort.SliceStable(sums, func(i, j int) bool {
+		if sums[i].ArticleCode != sums[j].ArticleCode {
+			return sums[i].ArticleCode < sums[j].ArticleCode
+		}
+
+		return sums[i].Kind < sums[j].Kind
+	})
+
+	return sums
+}
+
+// inboundQuantities sums the announced and received quantities of an inbound
+// per article code.
+func inboundQuantities(inbound *Inbound) map[string]*articleQuantities {
+	totals := map[string]*articleQuantities{}
+
+	for _, l := range inbound.InboundLines {
+		code := l.ArticleCode
+		if code == "" && l.Variant != nil {
+			code = l.Variant.ArticleCode
+		}
+
+		q, ok := totals[code]
+		if !ok {
+			q = &articleQuantities{}
+			totals[code] = q
+		}
+
+		q.expected += l.Quantity
+		q.received += l.ReceivedQuantity
+	}
+
+	return totals
+}
+
+func discrepancies(totals map[string]*articleQuantities) []InboundDiscrepancy {
+	var list []InboundDiscrepancy
+
+	for code, q := range totals {
+		d := InboundDiscrepancy{ArticleCode: code, Expected: q.expected, Received: q.received}
+
+		switch {
+		case q.expected == 0 && q.received > 0:
+			d.Kind = DiscrepancyUnexpected
+		case q.received < q.expected:
+			d.Kind = DiscrepancyShort
+		case q.received > q.expected:
+			d.Kind = DiscrepancyOver
+		default:
+			continue
+		}
+
+		list = append(list, d)
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].ArticleCode < list[j].ArticleCode })
+
+	return list
+}
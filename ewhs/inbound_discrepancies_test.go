@@ -0,0 +1,132 @@
+package ewhs
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ewarehousing-solutions/ewhs-api-go/test/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInboundsService_DiscrepancyReport(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+
+	tMux.HandleFunc("/wms/inbounds/4f6e4a3c-6a0e-4bd9-9b8e-2f5f0f5b8a10/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testdata.GetInboundResponse))
+	})
+
+	report, err := tClient.Inbounds.DiscrepancyReport(context.Background(), "4f6e4a3c-6a0e-4bd9-9b8e-2f5f0f5b8a10")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"4f6e4a3c-6a0e-4bd9-9b8e-2f5f0f5b8a10"}, report.Inbounds)
+	assert.Len(t, report.Discrepancies, 3)
+
+	short := report.Kind(DiscrepancyShort)
+	assert.Len(t, short, 1)
+	assert.Equal(t, "default_variant_a_id", short[0].ArticleCode)
+	assert.Equal(t, "PO-2022-0042", short[0].ExternalReference)
+	assert.Equal(t, -2, short[0].Difference())
+
+	over := report.Kind(DiscrepancyOver)
+	assert.Len(t, over, 1)
+	assert.Equal(t, 1, over[0].Difference())
+
+	unexpected := report.Kind(DiscrepancyUnexpected)
+	assert.Len(t, unexpected, 1)
+	assert.Equal(t, "unannounced_variant", unexpected[0].ArticleCode)
+}
+
+func TestInboundsService_DiscrepancyReportForRange(t *testing.T) {
+	list := `[
+		{"id": "i1", "status": "completed", "inbound_lines": [
+			{"article_code": "cream", "quantity": 10, "received_quantity": 7},
+			{"article_code": "soap", "quantity": 4, "received_quantity": 4}
+		]},
+		{"id": "i2", "status": "cancelled", "inbound_lines": [
+			{"article_code": "cream", "quantity": 10, "received_quantity": 0}
+		]},
+		{"id": "i3", "status": "completed"},
+		{"id": "i4", "status": "partially_received", "inbound_lines": [
+			{"article_code": "soap", "quantity": 8, "received_quantity": 2}
+		]}
+	]`
+
+	detail := `{"id": "i3", "status": "completed", "inbound_lines": [
+		{"article_code": "cream", "quantity": 5, "received_quantity": 9},
+		{"article_code": "soap", "quantity": 2, "received_quantity": 1}
+	]}`
+
+	tests := []struct {
+		name         string
+		statuses     []InboundStatus
+		aggregate    bool
+		wantInbounds []string
+		want         []InboundDiscrepancy
+	}{
+		{
+			"per inbound",
+			nil,
+			false,
+			[]string{"i1", "i3"},
+			[]InboundDiscrepancy{
+				{InboundID: "i1", ArticleCode: "cream", Kind: DiscrepancyShort, Expected: 10, Received: 7},
+				{InboundID: "i3", ArticleCode: "cream", Kind: DiscrepancyOver, Expected: 5, Received: 9},
+				{InboundID: "i3", ArticleCode: "soap", Kind: DiscrepancyShort, Expected: 2, Received: 1},
+			},
+		},
+		{
+			"aggregated without netting shortages against overages",
+			nil,
+			true,
+			[]string{"i1", "i3"},
+			[]InboundDiscrepancy{
+				{ArticleCode: "cream", Kind: DiscrepancyOver, Expected: 5, Received: 9},
+				{ArticleCode: "cream", Kind: DiscrepancyShort, Expected: 10, Received: 7},
+				{ArticleCode: "soap", Kind: DiscrepancyShort, Expected: 2, Received: 1},
+			},
+		},
+		{
+			"aggregated with partially received inbounds",
+			[]InboundStatus{InboundStatusCompleted, InboundStatusPartiallyReceived},
+			true,
+			[]string{"i1", "i3", "i4"},
+			[]InboundDiscrepancy{
+				{ArticleCode: "cream", Kind: DiscrepancyOver, Expected: 5, Received: 9},
+				{ArticleCode: "cream", Kind: DiscrepancyShort, Expected: 10, Received: 7},
+				{ArticleCode: "soap", Kind: DiscrepancyShort, Expected: 10, Received: 3},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup()
+			defer teardown()
+
+			tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+
+			tMux.HandleFunc("/wms/inbounds/", func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "2022-03-01", r.URL.Query().Get("from"))
+				assert.Equal(t, "2022-03-31", r.URL.Query().Get("to"))
+				_, _ = w.Write([]byte(list))
+			})
+			tMux.HandleFunc("/wms/inbounds/i3/", func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(detail))
+			})
+
+			report, err := tClient.Inbounds.DiscrepancyReportForRange(context.Background(), DiscrepancyReportOptions{
+				From:      time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC),
+				To:        time.Date(2022, 3, 31, 0, 0, 0, 0, time.UTC),
+				Statuses:  tt.statuses,
+				Aggregate: tt.aggregate,
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantInbounds, report.Inbounds)
+			assert.Equal(t, tt.want, report.Discrepancies)
+		})
+	}
+}
@@ -4,31 +4,89 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 )
 
 type InboundsService service
 
+type InboundStatus string
+
+const (
+	InboundStatusAnnounced         InboundStatus = "announced"
+	InboundStatusPartiallyReceived InboundStatus = "partially_received"
+	InboundStatusCompleted         InboundStatus = "completed"
+	InboundStatusCancelled         InboundStatus = "cancelled"
+)
+
 type Inbound struct {
+	ID                string        `json:"id,omitempty"`
+	Reference         string        `json:"reference,omitempty"`
 	ExternalReference string        `json:"external_reference,omitempty"`
+	Status            InboundStatus `json:"status,omitempty"`
+	Type              int           `json:"type,omitempty"`
 	Note              string        `json:"note,omitempty"`
 	InboundDate       string        `json:"inbound_date,omitempty"`
+	CreatedAt         *time.Time    `json:"created_at,omitempty"`
+	Supplier          *Supplier     `json:"supplier,omitempty"`
 	InboundLines      []InboundLine `json:"inbound_lines,omitempty"`
 }
+type Supplier struct {
+	ID        string `json:"id,omitempty"`
+	Code      string `json:"code,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Reference string `json:"reference,omitempty"`
+}
 type InboundLine struct {
-	Quantity    int    `json:"quantity,omitempty"`
-	ArticleCode string `json:"article_code,omitempty"`
+	ID               string   `json:"id,omitempty"`
+	Quantity         int      `json:"quantity,omitempty"`
+	ReceivedQuantity int      `json:"received_quantity,omitempty"`
+	ArticleCode      string   `json:"article_code,omitempty"`
+	LotNumber        string   `json:"lot_number,omitempty"`
+	ExpiryDate       string   `json:"expiry_date,omitempty"`
+	Variant          *Variant `json:"variant,omitempty"`
+}
+
+// InboundUpdate holds the fields to change with InboundsService.Patch. Only
+// the fields that are set are sent.
+type InboundUpdate struct {
+	Reference         Nullable[string]              `json:"reference,omitempty"`
+	ExternalReference Nullable[string]              `json:"external_reference,omitempty"`
+	Type              Nullable[int]                 `json:"type,omitempty"`
+	Note              Nullable[string]              `json:"note,omitempty"`
+	InboundDate       Nullable[string]              `json:"inbound_date,omitempty"`
+	Supplier          Nullable[SupplierUpdate]      `json:"supplier,omitempty"`
+	InboundLines      Nullable[[]InboundLineUpdate] `json:"inbound_lines,omitempty"`
+}
+
+// SupplierUpdate is the supplier in an InboundUpdate. Only the fields that
+// are set are sent.
+type SupplierUpdate struct {
+	ID        Nullable[string] `json:"id,omitempty"`
+	Code      Nullable[string] `json:"code,omitempty"`
+	Name      Nullable[string] `json:"name,omitempty"`
+	Reference Nullable[string] `json:"reference,omitempty"`
+}
+
+// InboundLineUpdate is an inbound line in an InboundUpdate. Only the fields
+// that are set are sent, so for example Quantity can be set to 0.
+type InboundLineUpdate struct {
+	Quantity    Nullable[int]    `json:"quantity,omitempty"`
+	ArticleCode Nullable[string] `json:"article_code,omitempty"`
+	LotNumber   Nullable[string] `json:"lot_number,omitempty"`
+	ExpiryDate  Nullable[string] `json:"expiry_date,omitempty"`
 }
 
 type InboundListOptions struct {
-	Reference string `url:"reference,omitempty"`
-	Status    string `url:"status,omitempty"`
-	Type      int    `url:"type,omitempty"`
-	Page      int    `url:"page,omitempty"`
-	From      string `url:"from,omitempty"`
-	To        string `url:"to,omitempty"`
-	Limit     int    `url:"limit,omitempty"`
-	Sort      string `url:"sort,omitempty"`
-	Direction string `url:"direction,omitempty"`
+	Reference         string        `url:"reference,omitempty"`
+	ExternalReference string        `url:"external_reference,omitempty"`
+	Status            InboundStatus `url:"status,omitempty"`
+	Type              int           `url:"type,omitempty"`
+	Page              int           `url:"page,omitempty"`
+	From              string        `url:"from,omitempty"`
+	To                string        `url:"to,omitempty"`
+	Limit             int           `url:"limit,omitempty"`
+	Sort              string        `url:"sort,omitempty"`
+	Direction         string        `url:"direction,omitempty"`
 }
 
 func (is *InboundsService) List(ctx context.Context, opts *InboundListOptions) (list *[]Inbound, res *Response, err error) {
@@ -61,10 +119,40 @@ func (is *InboundsService) Get(ctx context.Context, inboundID string) (inbound *
 	return
 }
 
+// FindByExternalReference returns the inbound with the given external
+// reference, or nil when there is none.
+func (is *InboundsService) FindByExternalReference(ctx context.Context, reference string) (inbound *Inbound, res *Response, err error) {
+	list, res, err := is.List(ctx, &InboundListOptions{ExternalReference: reference})
+	if err != nil || list == nil {
+		return
+	}
+
+	for i := range *list {
+		if (*list)[i].ExternalReference == reference {
+			return &(*list)[i], res, nil
+		}
+	}
+
+	return
+}
+
+// Create creates an inbound. When the outcome of the request is ambiguous,
+// the inbound is looked up by its external reference before it is created
+// again. It is not created again when the lookup fails or the inbound has no
+// external reference.
 func (is *InboundsService) Create(ctx context.Context, inb Inbound) (inbound *Inbound, res *Response, err error) {
-	res, err = is.client.post(ctx, "wms/inbounds/", inb, nil)
+	var lookup func(ctx context.Context) (bool, *Response, error)
+
+	if inb.ExternalReference != "" {
+		lookup = func(ctx context.Context) (bool, *Response, error) {
+			existing, res, err := is.FindByExternalReference(ctx, inb.ExternalReference)
+			inbound = existing
+			return existing != nil, res, err
+		}
+	}
 
-	if err != nil {
+	res, found, err := is.client.createWithGuard(ctx, "wms/inbounds/", inb, lookup)
+	if err != nil || found {
 		return
 	}
 
@@ -89,6 +177,20 @@ func (is *InboundsService) Update(ctx context.Context, inboundID string, inb Inb
 	return
 }
 
+// Patch changes the fields set in upd and leaves all other fields untouched.
+func (is *InboundsService) Patch(ctx context.Context, inboundID string, upd InboundUpdate) (inbound *Inbound, res *Response, err error) {
+	res, err = is.client.patch(ctx, fmt.Sprintf("wms/inbounds/%s/", inboundID), upd, nil)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &inbound); err != nil {
+		return
+	}
+
+	return
+}
+
 func (is *InboundsService) Cancel(ctx context.Context, inboundID string) (res *Response, err error) {
 	res, err = is.client.patch(ctx, fmt.Sprintf("wms/inbounds/%s/cancel/", inboundID), nil, nil)
 	if err != nil {
@@ -0,0 +1,90 @@
+package ewhs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/ewarehousing-solutions/ewhs-api-go/test/testdata"
+	"github.com/stretchr/testify/suite"
+)
+
+type inboundsServiceSuite struct{ suite.Suite }
+
+func (is *inboundsServiceSuite) TestInboundsService_Get() {
+	type args struct {
+		ctx     context.Context
+		inbound string
+	}
+	cases := []struct {
+		name    string
+		args    args
+		wantErr bool
+		err     error
+		pre     func()
+		handler http.HandlerFunc
+	}{
+		{
+			"get inbounds works as expected.",
+			args{
+				context.Background(),
+				"4f6e4a3c-6a0e-4bd9-9b8e-2f5f0f5b8a10",
+			},
+			false,
+			nil,
+			func() {
+				tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+			},
+			func(w http.ResponseWriter, r *http.Request) {
+				testHeader(is.T(), r, AuthHeader, "Bearer eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+				testMethod(is.T(), r, "GET")
+
+				_, _ = w.Write([]byte(testdata.GetInboundResponse))
+			},
+		},
+		{
+			"get inbounds, an error is returned from the server",
+			args{
+				context.Background(),
+				"4f6e4a3c-6a0e-4bd9-9b8e-2f5f0f5b8a10",
+			},
+			true,
+			fmt.Errorf("500 - 500 Internal Server Error"),
+			func() {
+				tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+			},
+			errorHandler,
+		},
+	}
+
+	for _, c := range cases {
+		setup()
+		defer teardown()
+
+		is.T().Run(c.name, func(t *testing.T) {
+			c.pre()
+			tMux.HandleFunc(fmt.Sprintf("/wms/inbounds/%s/", c.args.inbound), c.handler)
+
+			m, res, err := tClient.Inbounds.Get(c.args.ctx, c.args.inbound)
+			if c.wantErr {
+				is.NotNil(err)
+				is.EqualError(err, c.err.Error())
+			} else {
+				is.Nil(err)
+				is.IsType(&http.Response{}, res.Response)
+				is.Equal(c.args.inbound, m.ID)
+				is.Equal(InboundStatusPartiallyReceived, m.Status)
+				is.Equal("Acme Supplies", m.Supplier.Name)
+				is.Len(m.InboundLines, 3)
+				is.Equal(8, m.InboundLines[0].ReceivedQuantity)
+				is.Equal("L2022-03", m.InboundLines[1].LotNumber)
+				is.Equal("87557e7a-4f4d-44eb-bbf1-c9d83df90099", m.InboundLines[1].Variant.ID)
+			}
+		})
+	}
+}
+
+func TestInboundsService(t *testing.T) {
+	suite.Run(t, new(inboundsServiceSuite))
+}
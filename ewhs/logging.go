@@ -0,0 +1,205 @@
+package ewhs
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+const (
+	RequestIDHeader string = "X-Request-Id"
+
+	redacted string = "[REDACTED]"
+)
+
+// LogOptions configures the request logging of a Client.
+type LogOptions struct {
+	// Level is used for requests that completed with a successful status.
+	Level slog.Level
+	// ErrorLevel is used for transport errors and error responses.
+	ErrorLevel slog.Level
+	// DumpBodies adds the redacted headers and bodies to each log record.
+	DumpBodies bool
+}
+
+var defaultLogOptions = LogOptions{
+	Level:      slog.LevelDebug,
+	ErrorLevel: slog.LevelError,
+}
+
+// redactedFields are replaced wherever they occur in a dumped body.
+var redactedFields = map[string]bool{
+	"password":               true,
+	"token":                  true,
+	"refresh_token":          true,
+	"shipping_email":         true,
+	"shipping_contactperson": true,
+}
+
+// redactedAddressFields are replaced inside a shipping_address object.
+var redactedAddressFields = map[string]bool{
+	"addressed_to":           true,
+	"contact_person":         true,
+	"street":                 true,
+	"street2":                true,
+	"street_number":          true,
+	"street_number_addition": true,
+	"zipcode":                true,
+	"phone_number":           true,
+	"mobile_number":          true,
+	"fax_number":             true,
+	"email_address":          true,
+}
+
+type attemptKey struct{}
+
+// attemptFromContext returns the attempt number of the request, starting at 1.
+func attemptFromContext(ctx context.Context) int {
+	if n, ok := ctx.Value(attemptKey{}).(int); ok {
+		return n
+	}
+
+	return 1
+}
+
+// WithLogger enables request logging on the client. When opts is nil the
+// requests are logged at debug level and failures at error level, without
+// bodies. Passing a nil logger disables logging.
+func (c *Client) WithLogger(l *slog.Logger, opts *LogOptions) *Client {
+	c.logger = l
+	c.logOptions = defaultLogOptions
+
+	if opts != nil {
+		c.logOptions = *opts
+	}
+
+	return c
+}
+
+// loggingMiddleware logs every attempt of a request once it has completed.
+func (c *Client) loggingMiddleware(next RoundTrip) RoundTrip {
+	return func(req *http.Request) (*Response, error) {
+		start := time.Now()
+
+		res, err := next(req)
+		c.logRequest(req, res, err, time.Since(start))
+
+		return res, err
+	}
+}
+
+func (c *Client) logRequest(req *http.Request, res *Response, err error, duration time.Duration) {
+	if c.logger == nil {
+		return
+	}
+
+	ctx := req.Context()
+
+	level := c.logOptions.Level
+	if err != nil {
+		level = c.logOptions.ErrorLevel
+	}
+
+	if !c.logger.Enabled(ctx, level) {
+		return
+	}
+
+	requestID := req.Header.Get(RequestIDHeader)
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Int("attempt", attemptFromContext(ctx)),
+		slog.Duration("duration", duration),
+	}
+
+	if res != nil {
+		if id := res.Header.Get(RequestIDHeader); id != "" {
+			requestID = id
+		}
+
+		attrs = append(attrs, slog.Int("status", res.StatusCode))
+	}
+
+	if requestID != "" {
+		attrs = append(attrs, slog.String("request_id", requestID))
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+
+	if c.logOptions.DumpBodies {
+		attrs = append(attrs, slog.Any("request_headers", redactHeaders(req.Header)))
+
+		if req.GetBody != nil {
+			if body, err := req.GetBody(); err == nil {
+				data, _ := io.ReadAll(body)
+				attrs = append(attrs, slog.String("request_body", redactBody(data)))
+			}
+		}
+
+		if res != nil {
+			attrs = append(attrs, slog.String("response_body", redactBody(res.content)))
+		}
+	}
+
+	c.logger.LogAttrs(ctx, level, "ewhs api request", attrs...)
+}
+
+func redactHeaders(h http.Header) http.Header {
+	h = h.Clone()
+
+	if h.Get(AuthHeader) != "" {
+		h.Set(AuthHeader, redacted)
+	}
+
+	return h
+}
+
+// redactBody removes credentials and personal data from a JSON body. Bodies
+// that are not valid JSON are returned unchanged.
+func redactBody(data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return string(data)
+	}
+
+	out, err := json.Marshal(redactValue(v, redactedFields))
+	if err != nil {
+		return redacted
+	}
+
+	return string(out)
+}
+
+func redactValue(v interface{}, fields map[string]bool) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, val := range t {
+			switch {
+			case fields[k] || redactedFields[k]:
+				if val != nil {
+					t[k] = redacted
+				}
+			case k == "shipping_address":
+				t[k] = redactValue(val, redactedAddressFields)
+			default:
+				t[k] = redactValue(val, fields)
+			}
+		}
+	case []interface{}:
+		for i := range t {
+			t[i] = redactValue(t[i], fields)
+		}
+	}
+
+	return v
+}
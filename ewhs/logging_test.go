@@ -0,0 +1,99 @@
+package ewhs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ewarehousing-solutions/ewhs-api-go/test/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		contains []string
+		excludes []string
+	}{
+		{
+			"login credentials are redacted",
+			`{"username":"test_username","password":"secret"}`,
+			[]string{`"username":"test_username"`, `"password":"[REDACTED]"`},
+			[]string{"secret"},
+		},
+		{
+			"shipping address and email are redacted",
+			testdata.CreateOrderRequest,
+			[]string{`"city":"Heinenoord"`, `"shipping_email":"[REDACTED]"`, `"street":"[REDACTED]"`},
+			[]string{"john.doe@comcast.net", "Nijverheidsweg"},
+		},
+		{
+			"tokens are redacted",
+			testdata.CreateAuthTokenResponse,
+			[]string{`"token":"[REDACTED]"`, `"refresh_token":"[REDACTED]"`},
+			[]string{"eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9"},
+		},
+		{
+			"invalid json is returned as is",
+			"not found ok",
+			[]string{"not found ok"},
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := redactBody([]byte(tt.body))
+
+			for _, c := range tt.contains {
+				assert.Contains(t, got, c)
+			}
+
+			for _, e := range tt.excludes {
+				assert.NotContains(t, got, e)
+			}
+		})
+	}
+}
+
+func TestClient_WithLogger(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	tClient.WithLogger(logger, &LogOptions{Level: slog.LevelInfo, ErrorLevel: slog.LevelError, DumpBodies: true})
+	tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+
+	tMux.HandleFunc("/wms/orders/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(RequestIDHeader, "req-123")
+		_, _ = w.Write([]byte(testdata.GetOrderResponse))
+	})
+
+	_, _, err := tClient.Orders.Create(context.Background(), Order{
+		ShippingEmail:   "john.doe@comcast.net",
+		ShippingAddress: ShippingAddress{Street: "Nijverheidsweg", City: "Zwijndrecht"},
+	})
+	assert.Nil(t, err)
+
+	var record map[string]interface{}
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &record))
+
+	assert.Equal(t, "INFO", record["level"])
+	assert.Equal(t, http.MethodPost, record["method"])
+	assert.Equal(t, "/wms/orders/", record["path"])
+	assert.Equal(t, float64(http.StatusOK), record["status"])
+	assert.Equal(t, float64(1), record["attempt"])
+	assert.Equal(t, "req-123", record["request_id"])
+
+	out := buf.String()
+	assert.False(t, strings.Contains(out, "eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9"))
+	assert.False(t, strings.Contains(out, "john.doe@comcast.net"))
+	assert.False(t, strings.Contains(out, "Nijverheidsweg"))
+}
@@ -0,0 +1,206 @@
+package ewhs
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Names of the built-in middlewares, in the order they are run.
+const (
+	MiddlewareRetry   string = "retry"
+	MiddlewareLogging string = "logging"
+	MiddlewareExpand  string = "expand"
+	MiddlewareAuth    string = "auth"
+)
+
+const loginPath string = "wms/auth/login/"
+
+var (
+	errUnknownMiddleware   = errors.New("no middleware registered with this name")
+	errDuplicateMiddleware = errors.New("a middleware with this name is already registered")
+)
+
+// RoundTrip sends a prepared request and returns the API response.
+type RoundTrip func(req *http.Request) (*Response, error)
+
+// Middleware wraps a RoundTrip, for example to add headers, collect metrics
+// or short-circuit requests. Call next to continue the chain.
+type Middleware func(next RoundTrip) RoundTrip
+
+type namedMiddleware struct {
+	name       string
+	middleware Middleware
+}
+
+func (c *Client) defaultMiddlewares() []namedMiddleware {
+	return []namedMiddleware{
+		{MiddlewareRetry, c.retryMiddleware},
+		{MiddlewareLogging, c.loggingMiddleware},
+		{MiddlewareExpand, expandMiddleware},
+		{MiddlewareAuth, c.authMiddleware},
+	}
+}
+
+// Middlewares returns the names of the registered middlewares, outermost first.
+func (c *Client) Middlewares() []string {
+	c.middlewaresMu.RLock()
+	defer c.middlewaresMu.RUnlock()
+
+	names := make([]string, 0, len(c.middlewares))
+	for _, m := range c.middlewares {
+		names = append(names, m.name)
+	}
+
+	return names
+}
+
+// Use appends a middleware to the end of the chain, so it runs after the
+// built-in middlewares and sees the final request headers.
+func (c *Client) Use(name string, mw Middleware) error {
+	c.middlewaresMu.Lock()
+	defer c.middlewaresMu.Unlock()
+
+	if c.middlewareIndex(name) >= 0 {
+		return errDuplicateMiddleware
+	}
+
+	c.middlewares = append(c.middlewares, namedMiddleware{name, mw})
+
+	return nil
+}
+
+// UseBefore inserts a middleware in front of the middleware with the given name.
+func (c *Client) UseBefore(before string, name string, mw Middleware) error {
+	c.middlewaresMu.Lock()
+	defer c.middlewaresMu.Unlock()
+
+	if c.middlewareIndex(name) >= 0 {
+		return errDuplicateMiddleware
+	}
+
+	i := c.middlewareIndex(before)
+	if i < 0 {
+		return errUnknownMiddleware
+	}
+
+	c.insertMiddleware(i, name, mw)
+
+	return nil
+}
+
+// setMiddleware replaces the middleware with the given name or, when it is
+// not registered yet, inserts it at the index returned by at, which is called
+// with the chain locked.
+func (c *Client) setMiddleware(name string, mw Middleware, at func() int) {
+	c.middlewaresMu.Lock()
+	defer c.middlewaresMu.Unlock()
+
+	if i := c.middlewareIndex(name); i >= 0 {
+		c.replaceMiddleware(i, mw)
+		return
+	}
+
+	c.insertMiddleware(at(), name, mw)
+}
+
+// insertMiddleware inserts a middleware at index i. The slice is copied, so
+// chains built before keep working on the old one.
+func (c *Client) insertMiddleware(i int, name string, mw Middleware) {
+	mws := make([]namedMiddleware, 0, len(c.middlewares)+1)
+	mws = append(mws, c.middlewares[:i]...)
+	mws = append(mws, namedMiddleware{name, mw})
+	c.middlewares = append(mws, c.middlewares[i:]...)
+}
+
+// replaceMiddleware sets the middleware at index i on a copy of the slice, so
+// chains being built meanwhile keep reading the old one.
+func (c *Client) replaceMiddleware(i int, mw Middleware) {
+	mws := append([]namedMiddleware{}, c.middlewares...)
+	mws[i].middleware = mw
+	c.middlewares = mws
+}
+
+// ReplaceMiddleware swaps the middleware with the given name, keeping its position.
+func (c *Client) ReplaceMiddleware(name string, mw Middleware) error {
+	c.middlewaresMu.Lock()
+	defer c.middlewaresMu.Unlock()
+
+	i := c.middlewareIndex(name)
+	if i < 0 {
+		return errUnknownMiddleware
+	}
+
+	c.replaceMiddleware(i, mw)
+
+	return nil
+}
+
+// RemoveMiddleware removes the middleware with the given name from the chain.
+func (c *Client) RemoveMiddleware(name string) error {
+	c.middlewaresMu.Lock()
+	defer c.middlewaresMu.Unlock()
+
+	i := c.middlewareIndex(name)
+	if i < 0 {
+		return errUnknownMiddleware
+	}
+
+	c.middlewares = append(append([]namedMiddleware{}, c.middlewares[:i]...), c.middlewares[i+1:]...)
+
+	return nil
+}
+
+// middlewareIndex returns the index of the middleware with the given name, or
+// -1. The caller must hold middlewaresMu.
+func (c *Client) middlewareIndex(name string) int {
+	for i, m := range c.middlewares {
+		if m.name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (c *Client) chain(rt RoundTrip) RoundTrip {
+	c.middlewaresMu.RLock()
+	mws := c.middlewares
+	c.middlewaresMu.RUnlock()
+
+	for i := len(mws) - 1; i >= 0; i-- {
+		rt = mws[i].middleware(rt)
+	}
+
+	return rt
+}
+
+// authMiddleware authorizes the client when no token is known yet and adds
+// the bearer token to the request.
+func (c *Client) authMiddleware(next RoundTrip) RoundTrip {
+	return func(req *http.Request) (*Response, error) {
+		if strings.HasSuffix(req.URL.Path, loginPath) {
+			return next(req)
+		}
+
+		token, err := c.accessToken(req.Context())
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set(AuthHeader, strings.Join([]string{"Bearer", token}, " "))
+
+		return next(req)
+	}
+}
+
+// expandMiddleware copies the "Expand" context value to the expand header.
+func expandMiddleware(next RoundTrip) RoundTrip {
+	return func(req *http.Request) (*Response, error) {
+		if expand, ok := req.Context().Value("Expand").(string); ok {
+			req.Header.Set("Expand", expand)
+		}
+
+		return next(req)
+	}
+}
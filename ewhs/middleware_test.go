@@ -0,0 +1,157 @@
+package ewhs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ewarehousing-solutions/ewhs-api-go/test/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Middlewares(t *testing.T) {
+	setup()
+	defer teardown()
+
+	assert.Equal(t, []string{MiddlewareRetry, MiddlewareLogging, MiddlewareExpand, MiddlewareAuth}, tClient.Middlewares())
+
+	noop := func(next RoundTrip) RoundTrip { return next }
+
+	assert.Nil(t, tClient.Use("custom", noop))
+	assert.Equal(t, errDuplicateMiddleware, tClient.Use("custom", noop))
+	assert.Nil(t, tClient.UseBefore(MiddlewareLogging, "metrics", noop))
+	assert.Equal(t, errUnknownMiddleware, tClient.UseBefore("missing", "other", noop))
+	assert.Nil(t, tClient.RemoveMiddleware(MiddlewareRetry))
+	assert.Equal(t, errUnknownMiddleware, tClient.RemoveMiddleware(MiddlewareRetry))
+	assert.Nil(t, tClient.ReplaceMiddleware(MiddlewareExpand, noop))
+
+	assert.Equal(t, []string{"metrics", MiddlewareLogging, MiddlewareExpand, MiddlewareAuth, "custom"}, tClient.Middlewares())
+}
+
+func TestClient_UseCustomHeader(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+
+	var seen string
+	_ = tClient.Use("signature", func(next RoundTrip) RoundTrip {
+		return func(req *http.Request) (*Response, error) {
+			seen = req.Header.Get(AuthHeader)
+			req.Header.Set("X-Signature", "signed")
+			return next(req)
+		}
+	})
+
+	tMux.HandleFunc("/wms/orders/", func(w http.ResponseWriter, r *http.Request) {
+		testHeader(t, r, "X-Signature", "signed")
+		testHeader(t, r, "Expand", "order_lines")
+		_, _ = w.Write([]byte(testdata.GetOrderResponse))
+	})
+
+	_, _, err := tClient.Orders.Create(context.WithValue(context.Background(), "Expand", "order_lines"), Order{})
+	assert.Nil(t, err)
+	assert.Equal(t, "Bearer eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9", seen)
+}
+
+func TestClient_Retry(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		retries  int
+		status   int
+		wantHits int
+	}{
+		{"get is retried on service unavailable", http.MethodGet, 2, http.StatusServiceUnavailable, 3},
+		{"get is not retried without max retries", http.MethodGet, 0, http.StatusServiceUnavailable, 1},
+		{"get is not retried on not found", http.MethodGet, 2, http.StatusNotFound, 1},
+		{"post is never retried", http.MethodPost, 2, http.StatusServiceUnavailable, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup()
+			defer teardown()
+
+			tConf.MaxRetries = tt.retries
+			tConf.RetryWait = time.Millisecond
+			tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+
+			hits := 0
+			tMux.HandleFunc("/wms/orders/", func(w http.ResponseWriter, r *http.Request) {
+				hits++
+				w.WriteHeader(tt.status)
+			})
+
+			req, err := tClient.NewRequest(context.Background(), tt.method, "wms/orders/", nil)
+			assert.Nil(t, err)
+
+			_, err = tClient.Do(req)
+			assert.NotNil(t, err)
+			assert.Equal(t, tt.wantHits, hits)
+		})
+	}
+}
+
+func TestClient_MiddlewaresConcurrentUse(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+	tMux.HandleFunc("/wms/orders/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testdata.GetOrderResponse))
+	})
+
+	noop := func(next RoundTrip) RoundTrip { return next }
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			_, _, err := tClient.Orders.Get(context.Background(), "c9165f93-8301-4aaa-9f64-27f191c0c778")
+			assert.Nil(t, err)
+		}()
+
+		go func(i int) {
+			defer wg.Done()
+
+			name := fmt.Sprintf("custom-%d", i)
+			assert.Nil(t, tClient.Use(name, noop))
+			assert.Nil(t, tClient.UseBefore(MiddlewareAuth, name+"-before", noop))
+			assert.Nil(t, tClient.RemoveMiddleware(name))
+			assert.Nil(t, tClient.ReplaceMiddleware(MiddlewareExpand, expandMiddleware))
+			assert.Nil(t, tClient.WithRateLimit(1000, 10))
+			assert.Nil(t, tClient.WithCircuitBreaker(CircuitBreakerOptions{}))
+			assert.Equal(t, CircuitClosed, tClient.CircuitState("orders"))
+		}(i)
+	}
+
+	wg.Wait()
+
+	assert.Len(t, tClient.Middlewares(), 14)
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		name    string
+		attempt int
+		want    time.Duration
+	}{
+		{"first attempt", 1, 500 * time.Millisecond},
+		{"doubles per attempt", 3, 2 * time.Second},
+		{"capped", 10, maxRetryWait},
+		{"no overflow", 100, maxRetryWait},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, retryDelay(nil, defaultRetryWait, tt.attempt))
+		})
+	}
+}
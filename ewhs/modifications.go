@@ -0,0 +1,141 @@
+package ewhs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type ModificationsService service
+
+type ModificationStatus string
+
+const (
+	ModificationStatusCreated     ModificationStatus = "created"
+	ModificationStatusApproved    ModificationStatus = "approved"
+	ModificationStatusDisapproved ModificationStatus = "disapproved"
+	ModificationStatusProcessed   ModificationStatus = "processed"
+)
+
+type ModificationReason string
+
+const (
+	ModificationReasonStockCount ModificationReason = "stock_count"
+	ModificationReasonDamaged    ModificationReason = "damaged"
+	ModificationReasonExpired    ModificationReason = "expired"
+	ModificationReasonLost       ModificationReason = "lost"
+	ModificationReasonFound      ModificationReason = "found"
+	ModificationReasonReturned   ModificationReason = "returned"
+	ModificationReasonOther      ModificationReason = "other"
+)
+
+type Modification struct {
+	ID                string             `json:"id,omitempty"`
+	Reference         string             `json:"reference,omitempty"`
+	ExternalReference string             `json:"external_reference,omitempty"`
+	Status            ModificationStatus `json:"status,omitempty"`
+	Reason            ModificationReason `json:"reason,omitempty"`
+	Note              string             `json:"note,omitempty"`
+	CreatedAt         *time.Time         `json:"created_at,omitempty"`
+	ApprovedAt        *time.Time         `json:"approved_at,omitempty"`
+	ModificationLines []ModificationLine `json:"modification_lines,omitempty"`
+}
+
+// ModificationLine corrects the stock of one article. A positive quantity
+// adds stock, a negative quantity removes it.
+type ModificationLine struct {
+	ArticleCode string             `json:"article_code,omitempty"`
+	Quantity    int                `json:"quantity,omitempty"`
+	Reason      ModificationReason `json:"reason,omitempty"`
+	Note        string             `json:"note,omitempty"`
+	Variant     *Variant           `json:"variant,omitempty"`
+}
+
+type ModificationListOptions struct {
+	Reference string             `url:"reference,omitempty"`
+	Status    ModificationStatus `url:"status,omitempty"`
+	Reason    ModificationReason `url:"reason,omitempty"`
+	Page      int                `url:"page,omitempty"`
+	From      string             `url:"from,omitempty"`
+	To        string             `url:"to,omitempty"`
+	Limit     int                `url:"limit,omitempty"`
+	Sort      string             `url:"sort,omitempty"`
+	Direction string             `url:"direction,omitempty"`
+}
+
+func (ms *ModificationsService) List(ctx context.Context, opts *ModificationListOptions) (list *[]Modification, res *Response, err error) {
+	res, err = ms.client.get(ctx, "wms/modifications/", opts)
+	if err != nil {
+		return
+	}
+
+	if len(res.content) == 0 {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &list); err != nil {
+		return
+	}
+
+	return
+}
+
+func (ms *ModificationsService) Get(ctx context.Context, modificationID string) (modification *Modification, res *Response, err error) {
+	res, err = ms.client.get(ctx, fmt.Sprintf("wms/modifications/%s/", modificationID), nil)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &modification); err != nil {
+		return
+	}
+
+	return
+}
+
+func (ms *ModificationsService) Create(ctx context.Context, mod Modification) (modification *Modification, res *Response, err error) {
+	res, err = ms.client.post(ctx, "wms/modifications/", mod, nil)
+
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &modification); err != nil {
+		return
+	}
+
+	return
+}
+
+func (ms *ModificationsService) Update(ctx context.Context, modificationID string, mod Modification) (modification *Modification, res *Response, err error) {
+	res, err = ms.client.patch(ctx, fmt.Sprintf("wms/modifications/%s/", modificationID), mod, nil)
+
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &modification); err != nil {
+		return
+	}
+
+	return
+}
+
+func (ms *ModificationsService) Approve(ctx context.Context, modificationID string) (res *Response, err error) {
+	res, err = ms.client.patch(ctx, fmt.Sprintf("wms/modifications/%s/approve/", modificationID), nil, nil)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (ms *ModificationsService) Disapprove(ctx context.Context, modificationID string) (res *Response, err error) {
+	res, err = ms.client.patch(ctx, fmt.Sprintf("wms/modifications/%s/disapprove/", modificationID), nil, nil)
+	if err != nil {
+		return
+	}
+
+	return
+}
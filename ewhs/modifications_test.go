@@ -0,0 +1,189 @@
+package ewhs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ewarehousing-solutions/ewhs-api-go/test/testdata"
+	"github.com/stretchr/testify/suite"
+)
+
+const testModificationID = "5d4b4ba3-0d0c-4b8e-8d36-1c4f2d1c2a61"
+
+type modificationsServiceSuite struct{ suite.Suite }
+
+// testBody checks the JSON body of a request.
+func testBody(t *testing.T, r *http.Request, want string) {
+	b, _ := io.ReadAll(r.Body)
+	if got := strings.TrimSpace(string(b)); got != want {
+		t.Errorf("Body returned %q, want %q", got, want)
+	}
+}
+
+func (ms *modificationsServiceSuite) TestModificationsService_List() {
+	cases := []struct {
+		name      string
+		opts      *ModificationListOptions
+		wantQuery string
+		wantErr   bool
+		handler   func(t *testing.T, query string) http.HandlerFunc
+	}{
+		{
+			"list modifications works as expected.",
+			&ModificationListOptions{Status: ModificationStatusCreated, Reason: ModificationReasonStockCount, Page: 2, Limit: 10},
+			"limit=10&page=2&reason=stock_count&status=created",
+			false,
+			func(t *testing.T, query string) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					testMethod(t, r, http.MethodGet)
+					testQuery(t, r, query)
+					_, _ = w.Write([]byte(testdata.ListModificationsResponse))
+				}
+			},
+		},
+		{
+			"list modifications, an error is returned from the server",
+			nil,
+			"",
+			true,
+			func(t *testing.T, query string) http.HandlerFunc { return errorHandler },
+		},
+	}
+
+	for _, c := range cases {
+		setup()
+		defer teardown()
+
+		ms.T().Run(c.name, func(t *testing.T) {
+			tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+			tMux.HandleFunc("/wms/modifications/", c.handler(t, c.wantQuery))
+
+			list, _, err := tClient.Modifications.List(context.Background(), c.opts)
+			if c.wantErr {
+				ms.EqualError(err, fmt.Errorf("500 - 500 Internal Server Error").Error())
+				return
+			}
+
+			ms.Nil(err)
+			ms.Len(*list, 2)
+			ms.Equal(ModificationStatusApproved, (*list)[1].Status)
+			ms.NotNil((*list)[1].ApprovedAt)
+		})
+	}
+}
+
+func (ms *modificationsServiceSuite) TestModificationsService_Get() {
+	setup()
+	defer teardown()
+
+	tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+	tMux.HandleFunc("/wms/modifications/"+testModificationID+"/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(ms.T(), r, http.MethodGet)
+		testQuery(ms.T(), r, "")
+		_, _ = w.Write([]byte(testdata.GetModificationResponse))
+	})
+
+	m, res, err := tClient.Modifications.Get(context.Background(), testModificationID)
+	ms.Nil(err)
+	ms.IsType(&http.Response{}, res.Response)
+	ms.Equal(ModificationReasonStockCount, m.Reason)
+	ms.Nil(m.ApprovedAt)
+	ms.Equal([]ModificationLine{
+		{ArticleCode: "default_variant_a_id", Quantity: -2, Reason: ModificationReasonDamaged},
+		{ArticleCode: "default_variant_b_id", Quantity: 5, Reason: ModificationReasonFound},
+	}, m.ModificationLines)
+}
+
+func (ms *modificationsServiceSuite) TestModificationsService_Create() {
+	setup()
+	defer teardown()
+
+	tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+	tMux.HandleFunc("/wms/modifications/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(ms.T(), r, http.MethodPost)
+		testQuery(ms.T(), r, "")
+		testBody(ms.T(), r, `{"external_reference":"count-2022-10","reason":"stock_count",`+
+			`"modification_lines":[{"article_code":"default_variant_a_id","quantity":-2,"reason":"damaged"}]}`)
+		_, _ = w.Write([]byte(testdata.GetModificationResponse))
+	})
+
+	m, _, err := tClient.Modifications.Create(context.Background(), Modification{
+		ExternalReference: "count-2022-10",
+		Reason:            ModificationReasonStockCount,
+		ModificationLines: []ModificationLine{
+			{ArticleCode: "default_variant_a_id", Quantity: -2, Reason: ModificationReasonDamaged},
+		},
+	})
+	ms.Nil(err)
+	ms.Equal(testModificationID, m.ID)
+}
+
+func (ms *modificationsServiceSuite) TestModificationsService_Update() {
+	setup()
+	defer teardown()
+
+	tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+	tMux.HandleFunc("/wms/modifications/"+testModificationID+"/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(ms.T(), r, http.MethodPatch)
+		testQuery(ms.T(), r, "")
+		testBody(ms.T(), r, `{"note":"Recounted"}`)
+		_, _ = w.Write([]byte(testdata.GetModificationResponse))
+	})
+
+	m, _, err := tClient.Modifications.Update(context.Background(), testModificationID, Modification{Note: "Recounted"})
+	ms.Nil(err)
+	ms.Equal(testModificationID, m.ID)
+}
+
+func (ms *modificationsServiceSuite) TestModificationsService_ApproveAndDisapprove() {
+	cases := []struct {
+		name string
+		path string
+		call func(ctx context.Context, id string) (*Response, error)
+	}{
+		{
+			"approve",
+			"/wms/modifications/" + testModificationID + "/approve/",
+			func(ctx context.Context, id string) (*Response, error) { return tClient.Modifications.Approve(ctx, id) },
+		},
+		{
+			"disapprove",
+			"/wms/modifications/" + testModificationID + "/disapprove/",
+			func(ctx context.Context, id string) (*Response, error) {
+				return tClient.Modifications.Disapprove(ctx, id)
+			},
+		},
+	}
+
+	for _, c := range cases {
+		setup()
+		defer teardown()
+
+		ms.T().Run(c.name, func(t *testing.T) {
+			tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+
+			hits := 0
+			tMux.HandleFunc("/wms/modifications/", func(w http.ResponseWriter, r *http.Request) {
+				hits++
+				ms.Equal(c.path, r.URL.Path)
+				testMethod(t, r, http.MethodPatch)
+				testQuery(t, r, "")
+				testBody(t, r, "")
+				w.WriteHeader(http.StatusNoContent)
+			})
+
+			res, err := c.call(context.Background(), testModificationID)
+			ms.Nil(err)
+			ms.Equal(http.StatusNoContent, res.StatusCode)
+			ms.Equal(1, hits)
+		})
+	}
+}
+
+func TestModificationsService(t *testing.T) {
+	suite.Run(t, new(modificationsServiceSuite))
+}
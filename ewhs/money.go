@@ -0,0 +1,427 @@
+package ewhs
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var (
+	errCurrencyMismatch = errors.New("currencies do not match")
+	errInvalidAmount    = errors.New("invalid amount")
+)
+
+// currencyExponents holds the ISO 4217 currencies that do not have two
+// decimal places.
+var currencyExponents = map[string]int{
+	"BHD": 3, "BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "IQD": 3, "ISK": 0,
+	"JOD": 3, "JPY": 0, "KMF": 0, "KRW": 0, "KWD": 3, "LYD": 3, "OMR": 3,
+	"PYG": 0, "RWF": 0, "TND": 3, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0,
+	"XAF": 0, "XOF": 0, "XPF": 0,
+}
+
+// CurrencyExponent returns the number of decimal places of an ISO 4217
+// currency code.
+func CurrencyExponent(currency string) int {
+	if e, ok := currencyExponents[strings.ToUpper(currency)]; ok {
+		return e
+	}
+
+	return 2
+}
+
+// Money is an amount in the minor units of an ISO 4217 currency, e.g. 1295
+// EUR is € 12.95. Arithmetic is exact; amounts in different currencies cannot
+// be combined.
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// NewMoney returns an amount in minor units.
+func NewMoney(minor int64, currency string) Money {
+	return Money{Amount: minor, Currency: strings.ToUpper(currency)}
+}
+
+// ParseMoney parses a decimal amount in major units, e.g. "12.95". Digits
+// beyond the decimal places of the currency are rounded half away from zero.
+func ParseMoney(amount string, currency string) (Money, error) {
+	minor, err := parseDecimal(amount, CurrencyExponent(currency))
+	if err != nil {
+		return Money{}, err
+	}
+
+	return NewMoney(minor, currency), nil
+}
+
+// Add returns m + o.
+func (m Money) Add(o Money) (Money, error) {
+	if err := m.sameCurrency(o); err != nil {
+		return Money{}, err
+	}
+
+	return Money{Amount: m.Amount + o.Amount, Currency: m.currency(o)}, nil
+}
+
+// Sub returns m - o.
+func (m Money) Sub(o Money) (Money, error) {
+	if err := m.sameCurrency(o); err != nil {
+		return Money{}, err
+	}
+
+	return Money{Amount: m.Amount - o.Amount, Currency: m.currency(o)}, nil
+}
+
+// Mul returns m multiplied by a quantity.
+func (m Money) Mul(quantity int64) Money {
+	return Money{Amount: m.Amount * quantity, Currency: m.Currency}
+}
+
+// Allocate splits m into n parts that add up to m exactly. The remainder is
+// spread over the first parts.
+func (m Money) Allocate(n int) []Money {
+	if n <= 0 {
+		return nil
+	}
+
+	parts := make([]Money, n)
+	share, rest := m.Amount/int64(n), m.Amount%int64(n)
+
+	for i := range parts {
+		parts[i] = Money{Amount: share, Currency: m.Currency}
+
+		switch {
+		case rest > 0:
+			parts[i].Amount++
+			rest--
+		case rest < 0:
+			parts[i].Amount--
+			rest++
+		}
+	}
+
+	return parts
+}
+
+// Sum returns the total of amounts in the same currency.
+func Sum(amounts ...Money) (Money, error) {
+	var total Money
+
+	for _, a := range amounts {
+		var err error
+		if total, err = total.Add(a); err != nil {
+			return Money{}, err
+		}
+	}
+
+	return total, nil
+}
+
+// IsZero reports whether the amount is zero.
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// Decimal formats the amount in major units, e.g. "12.95".
+func (m Money) Decimal() string {
+	return formatDecimal(m.Amount, CurrencyExponent(m.Currency))
+}
+
+// String formats the amount with its currency, e.g. "12.95 EUR".
+func (m Money) String() string {
+	if m.Currency == "" {
+		return m.Decimal()
+	}
+
+	return m.Decimal() + " " + m.Currency
+}
+
+// MajorUnits converts m to the wire format of prices and values, with the
+// decimal places of its currency.
+func (m Money) MajorUnits() MajorUnits {
+	return NewMajorUnits(m.Amount, CurrencyExponent(m.Currency))
+}
+
+// MinorUnits converts m to the wire format of order amounts.
+func (m Money) MinorUnits() MinorUnits {
+	return MinorUnits(m.Amount)
+}
+
+func (m Money) sameCurrency(o Money) error {
+	if m.Currency != "" && o.Currency != "" && m.Currency != o.Currency {
+		return fmt.Errorf("%w: %s and %s", errCurrencyMismatch, m.Currency, o.Currency)
+	}
+
+	return nil
+}
+
+func (m Money) currency(o Money) string {
+	if m.Currency != "" {
+		return m.Currency
+	}
+
+	return o.Currency
+}
+
+// maxDecimalPlaces is the number of decimal places MajorUnits can hold.
+const maxDecimalPlaces int = 18
+
+// MajorUnits is a decimal amount of major units, such as OrderLine.Price and
+// Variant.Value, e.g. "12.95". It keeps the decimal places it was created or
+// decoded with, so 1.235 KWD is sent as 1.235, and is sent as a JSON number.
+// The empty MajorUnits is zero and is left out. Use Money.MajorUnits,
+// NewMajorUnits or ParseMajorUnits to create one.
+type MajorUnits string
+
+// NewMajorUnits returns an amount with the given number of decimal places,
+// e.g. NewMajorUnits(1295, 2) is 12.95.
+func NewMajorUnits(amount int64, decimals int) MajorUnits {
+	return MajorUnits(formatDecimal(amount, decimals))
+}
+
+// ParseMajorUnits parses a decimal number, keeping its decimal places.
+func ParseMajorUnits(s string) (MajorUnits, error) {
+	s = strings.TrimSpace(s)
+
+	decimals, err := decimalPlaces(s)
+	if err != nil {
+		return "", err
+	}
+
+	v, err := parseDecimal(s, decimals)
+	if err != nil {
+		return "", err
+	}
+
+	return NewMajorUnits(v, decimals), nil
+}
+
+// Money returns the amount in the given currency. Digits beyond the decimal
+// places of the currency are rounded half away from zero.
+func (a MajorUnits) Money(currency string) (Money, error) {
+	if a == "" {
+		return NewMoney(0, currency), nil
+	}
+
+	if _, err := decimalPlaces(string(a)); err != nil {
+		return Money{}, err
+	}
+
+	minor, err := parseDecimal(string(a), CurrencyExponent(currency))
+	if err != nil {
+		return Money{}, err
+	}
+
+	return NewMoney(minor, currency), nil
+}
+
+// Equal reports whether a and o are the same amount, so 7.5 equals 7.50.
+func (a MajorUnits) Equal(o MajorUnits) bool {
+	x, okA := a.rat()
+	y, okO := o.rat()
+	if !okA || !okO {
+		return a == o
+	}
+
+	return x.Cmp(y) == 0
+}
+
+func (a MajorUnits) rat() (*big.Rat, bool) {
+	if a == "" {
+		return new(big.Rat), true
+	}
+
+	if _, err := decimalPlaces(string(a)); err != nil {
+		return nil, false
+	}
+
+	return new(big.Rat).SetString(strings.TrimSpace(string(a)))
+}
+
+// MarshalJSON interface compliance.
+func (a MajorUnits) MarshalJSON() ([]byte, error) {
+	if a == "" {
+		return []byte("0"), nil
+	}
+
+	v, err := ParseMajorUnits(string(a))
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(v), nil
+}
+
+// UnmarshalJSON interface compliance.
+func (a *MajorUnits) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+
+	v, err := ParseMajorUnits(strings.Trim(s, `"`))
+	if err != nil {
+		return err
+	}
+
+	*a = v
+
+	return nil
+}
+
+// decimalPlaces returns the number of decimal places of a decimal number in
+// plain or exponent notation.
+func decimalPlaces(s string) (int, error) {
+	mantissa, exponent, hasExponent := strings.Cut(strings.ToLower(strings.TrimSpace(s)), "e")
+	if mantissa == "" || strings.Contains(mantissa, "/") {
+		return 0, fmt.Errorf("%w: %q", errInvalidAmount, s)
+	}
+
+	places := 0
+	if _, frac, ok := strings.Cut(mantissa, "."); ok {
+		places = len(frac)
+	}
+
+	if hasExponent {
+		e, err := strconv.Atoi(exponent)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q", errInvalidAmount, s)
+		}
+
+		places -= e
+	}
+
+	if places < 0 {
+		places = 0
+	}
+
+	if places > maxDecimalPlaces {
+		return 0, fmt.Errorf("%w: %q", errInvalidAmount, s)
+	}
+
+	return places, nil
+}
+
+// MinorUnits is an amount sent as an integer number of minor units, such as
+// Order.OrderAmount and Document.OrderPrice.
+type MinorUnits int64
+
+// Money returns the amount in the given currency.
+func (a MinorUnits) Money(currency string) Money {
+	return NewMoney(int64(a), currency)
+}
+
+// Amount returns the order amount in the currency of the order.
+func (o Order) Amount() Money {
+	return o.OrderAmount.Money(o.Currency)
+}
+
+// Total returns the price of an order line multiplied by its quantity,
+// rounded once to the decimal places of the currency, so 100 × 0.125 EUR is
+// 12.50 EUR.
+func (l OrderLine) Total(currency string) (Money, error) {
+	t, err := l.total()
+	if err != nil {
+		return Money{}, err
+	}
+
+	minor, err := roundRat(t, CurrencyExponent(currency))
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q × %d", err, l.Price, l.Quantity)
+	}
+
+	return NewMoney(minor, currency), nil
+}
+
+// total returns the exact price of an order line multiplied by its quantity.
+func (l OrderLine) total() (*big.Rat, error) {
+	price, ok := l.Price.rat()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", errInvalidAmount, l.Price)
+	}
+
+	return price.Mul(price, new(big.Rat).SetInt64(int64(l.Quantity))), nil
+}
+
+// LinesTotal returns the sum of the order line totals in the currency of the
+// order. The exact totals are added up and rounded once.
+func (o Order) LinesTotal() (Money, error) {
+	total := new(big.Rat)
+
+	for _, l := range o.OrderLines {
+		t, err := l.total()
+		if err != nil {
+			return Money{}, err
+		}
+
+		total.Add(total, t)
+	}
+
+	minor, err := roundRat(total, CurrencyExponent(o.Currency))
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: order lines total %s", err, total.FloatString(maxDecimalPlaces))
+	}
+
+	return NewMoney(minor, o.Currency), nil
+}
+
+// parseDecimal parses a decimal number into an integer with exp decimal
+// places, rounding half away from zero.
+func parseDecimal(s string, exp int) (int64, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", errInvalidAmount, s)
+	}
+
+	v, err := roundRat(r, exp)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", err, s)
+	}
+
+	return v, nil
+}
+
+// roundRat returns r as an integer with exp decimal places, rounding half away
+// from zero.
+func roundRat(r *big.Rat, exp int) (int64, error) {
+	r = new(big.Rat).Mul(r, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil)))
+
+	q, m := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(m), big.NewInt(2)).Cmp(r.Denom()) >= 0 {
+		q.Add(q, big.NewInt(int64(r.Sign())))
+	}
+
+	if !q.IsInt64() {
+		return 0, errInvalidAmount
+	}
+
+	return q.Int64(), nil
+}
+
+func formatDecimal(v int64, exp int) string {
+	if exp == 0 {
+		return strconv.FormatInt(v, 10)
+	}
+
+	sign := ""
+	if v < 0 {
+		sign = "-"
+	}
+
+	abs := strconv.FormatUint(absInt64(v), 10)
+	if len(abs) <= exp {
+		abs = strings.Repeat("0", exp-len(abs)+1) + abs
+	}
+
+	return sign + abs[:len(abs)-exp] + "." + abs[len(abs)-exp:]
+}
+
+func absInt64(v int64) uint64 {
+	if v < 0 {
+		return uint64(-v)
+	}
+
+	return uint64(v)
+}
@@ -0,0 +1,154 @@
+package ewhs
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ewarehousing-solutions/ewhs-api-go/test/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMajorUnits_JSON(t *testing.T) {
+	tests := []struct {
+		json string
+		want MajorUnits
+	}{
+		{`12.95`, "12.95"},
+		{`0.1`, "0.1"},
+		{`0.0`, "0.0"},
+		{`1.235`, "1.235"},
+		{`-4.005`, "-4.005"},
+		{`"7.50"`, "7.50"},
+		{`1e2`, "100"},
+		{`1.5e-1`, "0.15"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.json, func(t *testing.T) {
+			var a MajorUnits
+			assert.Nil(t, json.Unmarshal([]byte(tt.json), &a))
+			assert.Equal(t, tt.want, a)
+
+			b, err := json.Marshal(a)
+			assert.Nil(t, err)
+			assert.Equal(t, string(tt.want), string(b))
+		})
+	}
+
+	var a MajorUnits
+	assert.NotNil(t, json.Unmarshal([]byte(`"twelve"`), &a))
+	assert.NotNil(t, json.Unmarshal([]byte(`"1/2"`), &a))
+
+	_, err := json.Marshal(MajorUnits("12,95"))
+	assert.NotNil(t, err)
+
+	assert.True(t, MajorUnits("7.5").Equal("7.50"))
+	assert.True(t, MajorUnits("").Equal("0.00"))
+	assert.False(t, MajorUnits("7.5").Equal("7.05"))
+}
+
+func TestMoney_WireFormats(t *testing.T) {
+	var order struct {
+		Documents  []Document  `json:"documents"`
+		OrderLines []OrderLine `json:"order_lines"`
+	}
+	assert.Nil(t, json.Unmarshal([]byte(testdata.CreateOrderRequest), &order))
+	assert.Equal(t, MinorUnits(213), order.Documents[0].OrderPrice)
+	assert.Equal(t, MajorUnits("0.0"), order.OrderLines[0].Price)
+
+	b, err := json.Marshal(OrderLine{Price: NewMajorUnits(1295, 2), Quantity: 3})
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"price":12.95,"quantity":3}`, string(b))
+
+	b, err = json.Marshal(Order{OrderAmount: 3885, Currency: "EUR"})
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"order_amount":3885,"currency":"EUR","shipping_address":{}}`, string(b))
+
+	b, err = json.Marshal(Variant{})
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{}`, string(b), "zero amounts are left out")
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	price, err := ParseMoney("0.10", "eur")
+	assert.Nil(t, err)
+	assert.Equal(t, Money{Amount: 10, Currency: "EUR"}, price)
+
+	total, err := Sum(price, price, price)
+	assert.Nil(t, err)
+	assert.Equal(t, "0.30 EUR", total.String(), "no float rounding")
+
+	diff, err := total.Sub(NewMoney(45, "EUR"))
+	assert.Nil(t, err)
+	assert.Equal(t, "-0.15", diff.Decimal())
+
+	_, err = price.Add(NewMoney(10, "USD"))
+	assert.True(t, errors.Is(err, errCurrencyMismatch))
+
+	assert.Equal(t, []Money{NewMoney(34, "EUR"), NewMoney(33, "EUR"), NewMoney(33, "EUR")}, NewMoney(100, "EUR").Allocate(3))
+
+	yen, err := MajorUnits("1500.00").Money("JPY")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1500), yen.Amount)
+	assert.Equal(t, "1500 JPY", yen.String())
+	assert.Equal(t, MajorUnits("1500"), yen.MajorUnits())
+
+	dinar, _ := ParseMoney("1.2345", "KWD")
+	assert.Equal(t, int64(1235), dinar.Amount)
+	assert.Equal(t, "1.235", dinar.Decimal())
+	assert.Equal(t, MajorUnits("1.235"), dinar.MajorUnits(), "the decimals of the currency are kept")
+
+	b, err := json.Marshal(OrderLine{Price: dinar.MajorUnits()})
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"price":1.235}`, string(b))
+
+	euro, err := MajorUnits("19.995").Money("EUR")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2000), euro.Amount)
+
+	_, err = MajorUnits("twelve").Money("EUR")
+	assert.True(t, errors.Is(err, errInvalidAmount))
+}
+
+func TestOrder_Totals(t *testing.T) {
+	order := Order{
+		Currency:    "EUR",
+		OrderAmount: 4085,
+		OrderLines: []OrderLine{
+			{Price: "12.95", Quantity: 3},
+			{Price: "2", Quantity: 1},
+		},
+	}
+
+	assert.Equal(t, NewMoney(4085, "EUR"), order.Amount())
+	total, err := order.OrderLines[0].Total("EUR")
+	assert.Nil(t, err)
+	assert.Equal(t, NewMoney(3885, "EUR"), total)
+
+	total, err = order.LinesTotal()
+	assert.Nil(t, err)
+	assert.Equal(t, order.Amount(), total)
+}
+
+func TestOrder_TotalsRoundOnce(t *testing.T) {
+	order := Order{
+		Currency: "EUR",
+		OrderLines: []OrderLine{
+			{Price: "0.125", Quantity: 100},
+			{Price: "0.005", Quantity: 1},
+			{Price: "0.005", Quantity: 1},
+		},
+	}
+
+	total, err := order.OrderLines[0].Total("EUR")
+	assert.Nil(t, err)
+	assert.Equal(t, NewMoney(1250, "EUR"), total)
+
+	total, err = order.LinesTotal()
+	assert.Nil(t, err)
+	assert.Equal(t, NewMoney(1251, "EUR"), total)
+
+	_, err = OrderLine{Price: "twelve", Quantity: 1}.Total("EUR")
+	assert.True(t, errors.Is(err, errInvalidAmount))
+}
@@ -0,0 +1,100 @@
+package ewhs
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Nullable is a JSON value that is either absent, null or set. It is used
+// for fields the API returns as null where null means something else than
+// the zero value, such as a SKU that was never assigned.
+//
+// Most fields of the models are plain values that decode null as the zero
+// value; Nullable is only used where the difference matters, such as
+// Stock.Sku. The update types used with Patch (OrderUpdate, ArticleUpdate,
+// VariantUpdate, InboundUpdate) use Nullable for every field, so that a field
+// can be left out, cleared with null or set to its zero value.
+//
+// Nullable is a map so that omitempty leaves out an absent value: a nil
+// Nullable is absent, a Nullable holding false is null and a Nullable holding
+// true has a value.
+type Nullable[T any] map[bool]T
+
+// NewNullable returns a Nullable set to v.
+func NewNullable[T any](v T) Nullable[T] {
+	return Nullable[T]{true: v}
+}
+
+// Null returns a Nullable set to null.
+func Null[T any]() Nullable[T] {
+	var zero T
+	return Nullable[T]{false: zero}
+}
+
+// Get returns the value and whether it is set and not null.
+func (n Nullable[T]) Get() (T, bool) {
+	v, ok := n[true]
+	return v, ok
+}
+
+// ValueOr returns the value, or def when it is absent or null.
+func (n Nullable[T]) ValueOr(def T) T {
+	if v, ok := n[true]; ok {
+		return v
+	}
+
+	return def
+}
+
+// IsSpecified reports whether the value is present, either null or set.
+func (n Nullable[T]) IsSpecified() bool {
+	return len(n) != 0
+}
+
+// IsNull reports whether the value is explicitly null.
+func (n Nullable[T]) IsNull() bool {
+	_, ok := n[false]
+	return ok
+}
+
+// Set sets the value to v.
+func (n *Nullable[T]) Set(v T) {
+	*n = NewNullable(v)
+}
+
+// SetNull sets the value to null.
+func (n *Nullable[T]) SetNull() {
+	*n = Null[T]()
+}
+
+// Unset makes the value absent.
+func (n *Nullable[T]) Unset() {
+	*n = nil
+}
+
+// MarshalJSON interface compliance.
+func (n Nullable[T]) MarshalJSON() ([]byte, error) {
+	v, ok := n[true]
+	if !ok {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON interface compliance.
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		n.SetNull()
+		return nil
+	}
+
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	n.Set(v)
+
+	return nil
+}
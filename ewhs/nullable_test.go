@@ -0,0 +1,75 @@
+package ewhs
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNullable_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name          string
+		json          string
+		wantSpecified bool
+		wantNull      bool
+		wantValue     string
+	}{
+		{"absent", `{}`, false, false, ""},
+		{"null", `{"sku":null}`, true, true, ""},
+		{"empty string", `{"sku":""}`, true, false, ""},
+		{"value", `{"sku":"default_variant_a_id"}`, true, false, "default_variant_a_id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s Stock
+			assert.Nil(t, json.Unmarshal([]byte(tt.json), &s))
+
+			assert.Equal(t, tt.wantSpecified, s.Sku.IsSpecified())
+			assert.Equal(t, tt.wantNull, s.Sku.IsNull())
+
+			v, ok := s.Sku.Get()
+			assert.Equal(t, tt.wantSpecified && !tt.wantNull, ok)
+			assert.Equal(t, tt.wantValue, v)
+
+			b, err := json.Marshal(struct {
+				Sku Nullable[string] `json:"sku,omitempty"`
+			}{s.Sku})
+			assert.Nil(t, err)
+			assert.JSONEq(t, tt.json, string(b))
+		})
+	}
+}
+
+func TestNullable_Set(t *testing.T) {
+	var n Nullable[int]
+	assert.Equal(t, 7, n.ValueOr(7))
+
+	n.Set(0)
+	v, ok := n.Get()
+	assert.True(t, ok)
+	assert.Equal(t, 0, v)
+
+	n.SetNull()
+	assert.True(t, n.IsNull())
+
+	n.Unset()
+	assert.False(t, n.IsSpecified())
+
+	b, _ := json.Marshal(n)
+	assert.Equal(t, "null", string(b))
+}
+
+func TestShipment_NullableFields(t *testing.T) {
+	var s Shipment
+	err := json.Unmarshal([]byte(`{
+		"shipment_external_reference": null,
+		"shipment_lines": [{"shipped_quantity": 1, "serial_numbers": ["SN1", "SN2"]}, {"serial_numbers": null}]
+	}`), &s)
+
+	assert.Nil(t, err)
+	assert.True(t, s.ShipmentExternalReference.IsNull())
+	assert.Equal(t, []string{"SN1", "SN2"}, s.ShipmentLines[0].SerialNumbers)
+	assert.Nil(t, s.ShipmentLines[1].SerialNumbers)
+}
@@ -0,0 +1,182 @@
+package ewhs
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MaxDocumentSize is the largest document, before base64 encoding, that is
+// uploaded to the API.
+const MaxDocumentSize int = 10 << 20
+
+var ErrDocumentTooLarge = fmt.Errorf("document exceeds the maximum size of %d bytes", MaxDocumentSize)
+
+var errEmptyDocument = errors.New("document has no file contents")
+
+// OrderDocuments manages the documents of an existing order.
+type OrderDocuments struct {
+	client  *Client
+	orderID string
+}
+
+// Documents returns the document operations for the given order.
+func (os *OrdersService) Documents(orderID string) *OrderDocuments {
+	return &OrderDocuments{client: os.client, orderID: orderID}
+}
+
+// NewDocument reads a file, typically a PDF, and returns a document with its
+// base64 encoded contents.
+func NewDocument(title string, r io.Reader) (Document, error) {
+	data, err := io.ReadAll(io.LimitReader(r, int64(MaxDocumentSize)+1))
+	if err != nil {
+		return Document{}, err
+	}
+
+	if len(data) > MaxDocumentSize {
+		return Document{}, ErrDocumentTooLarge
+	}
+
+	return Document{
+		Title:    title,
+		Quantity: 1,
+		File:     base64.StdEncoding.EncodeToString(data),
+	}, nil
+}
+
+// NewDocumentFromFile reads the file at path into a document titled after the
+// file name.
+func NewDocumentFromFile(path string) (Document, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Document{}, err
+	}
+
+	defer f.Close()
+
+	return NewDocument(filepath.Base(path), f)
+}
+
+// Content returns the decoded file of the document.
+func (d Document) Content() (io.Reader, error) {
+	data, err := base64.StdEncoding.DecodeString(d.File)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.NewReader(data), nil
+}
+
+func checkDocument(doc Document) error {
+	if doc.File == "" {
+		return errEmptyDocument
+	}
+
+	if decodedLen(doc.File) > MaxDocumentSize {
+		return ErrDocumentTooLarge
+	}
+
+	return nil
+}
+
+// decodedLen returns the exact number of bytes a padded base64 string
+// decodes to.
+func decodedLen(s string) int {
+	n := base64.StdEncoding.DecodedLen(len(s))
+
+	for i := 0; i < 2 && strings.HasSuffix(s, "="); i++ {
+		s = s[:len(s)-1]
+		n--
+	}
+
+	return n
+}
+
+func (od *OrderDocuments) List(ctx context.Context) (list *[]Document, res *Response, err error) {
+	res, err = od.client.get(ctx, fmt.Sprintf("wms/orders/%s/documents/", od.orderID), nil)
+	if err != nil {
+		return
+	}
+
+	if len(res.content) == 0 {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &list); err != nil {
+		return
+	}
+
+	return
+}
+
+func (od *OrderDocuments) Add(ctx context.Context, doc Document) (document *Document, res *Response, err error) {
+	if err = checkDocument(doc); err != nil {
+		return
+	}
+
+	res, err = od.client.post(ctx, fmt.Sprintf("wms/orders/%s/documents/", od.orderID), doc, nil)
+
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &document); err != nil {
+		return
+	}
+
+	return
+}
+
+// AddFile uploads the file at path as a new document of the order.
+func (od *OrderDocuments) AddFile(ctx context.Context, path string) (document *Document, res *Response, err error) {
+	doc, err := NewDocumentFromFile(path)
+	if err != nil {
+		return
+	}
+
+	return od.Add(ctx, doc)
+}
+
+// AddReader uploads the contents of r as a new document of the order.
+func (od *OrderDocuments) AddReader(ctx context.Context, title string, r io.Reader) (document *Document, res *Response, err error) {
+	doc, err := NewDocument(title, r)
+	if err != nil {
+		return
+	}
+
+	return od.Add(ctx, doc)
+}
+
+func (od *OrderDocuments) Replace(ctx context.Context, documentID string, doc Document) (document *Document, res *Response, err error) {
+	if err = checkDocument(doc); err != nil {
+		return
+	}
+
+	res, err = od.client.patch(ctx, fmt.Sprintf("wms/orders/%s/documents/%s/", od.orderID, documentID), doc, nil)
+
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &document); err != nil {
+		return
+	}
+
+	return
+}
+
+func (od *OrderDocuments) Delete(ctx context.Context, documentID string) (res *Response, err error) {
+	res, err = od.client.delete(ctx, fmt.Sprintf("wms/orders/%s/documents/%s/", od.orderID, documentID), nil)
+	if err != nil {
+		return
+	}
+
+	return
+}
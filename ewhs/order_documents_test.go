@@ -0,0 +1,84 @@
+package ewhs
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDocument(t *testing.T) {
+	doc, err := NewDocument("label.pdf", bytes.NewReader([]byte("%PDF-1.4")))
+	assert.Nil(t, err)
+	assert.Equal(t, "label.pdf", doc.Title)
+	assert.Equal(t, "JVBERi0xLjQ=", doc.File)
+
+	content, err := doc.Content()
+	assert.Nil(t, err)
+	data, _ := io.ReadAll(content)
+	assert.Equal(t, "%PDF-1.4", string(data))
+
+	_, err = NewDocument("large.pdf", bytes.NewReader(make([]byte, MaxDocumentSize+1)))
+	assert.Equal(t, ErrDocumentTooLarge, err)
+}
+
+func TestCheckDocument(t *testing.T) {
+	tests := []struct {
+		name string
+		size int
+		want error
+	}{
+		{"maximum size without padding", MaxDocumentSize - 1, nil},
+		{"maximum size with padding", MaxDocumentSize, nil},
+		{"one byte too large", MaxDocumentSize + 1, ErrDocumentTooLarge},
+		{"two bytes too large", MaxDocumentSize + 2, ErrDocumentTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := Document{File: base64.StdEncoding.EncodeToString(make([]byte, tt.size))}
+			assert.Equal(t, tt.want, checkDocument(doc))
+		})
+	}
+
+	assert.Equal(t, 0, decodedLen(""))
+	assert.Equal(t, 1, decodedLen("AA=="))
+	assert.Equal(t, 2, decodedLen("AAA="))
+	assert.Equal(t, 3, decodedLen("AAAA"))
+}
+
+func TestOrderDocuments_AddFile(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+
+	path := filepath.Join(t.TempDir(), "invoice.pdf")
+	assert.Nil(t, os.WriteFile(path, []byte("%PDF-1.4"), 0o600))
+
+	tMux.HandleFunc("/wms/orders/c9165f93-8301-4aaa-9f64-27f191c0c778/documents/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		var doc Document
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&doc))
+		assert.Equal(t, "invoice.pdf", doc.Title)
+		assert.Equal(t, "JVBERi0xLjQ=", doc.File)
+
+		doc.ID = "0b5e4f3e-5d5c-4ae0-a0a4-5c2d1c4e0a11"
+		_ = json.NewEncoder(w).Encode(doc)
+	})
+
+	doc, _, err := tClient.Orders.Documents("c9165f93-8301-4aaa-9f64-27f191c0c778").AddFile(context.Background(), path)
+	assert.Nil(t, err)
+	assert.Equal(t, "0b5e4f3e-5d5c-4ae0-a0a4-5c2d1c4e0a11", doc.ID)
+
+	_, _, err = tClient.Orders.Documents("c9165f93-8301-4aaa-9f64-27f191c0c778").Add(context.Background(), Document{Title: "empty"})
+	assert.Equal(t, errEmptyDocument, err)
+}
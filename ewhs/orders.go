@@ -19,7 +19,7 @@ type Order struct {
 	ShippingMethod        string            `json:"shipping_method,omitempty"`
 	Note                  string            `json:"note,omitempty"`
 	Currency              string            `json:"currency,omitempty"`
-	OrderAmount           int64             `json:"order_amount,omitempty"`
+	OrderAmount           MinorUnits        `json:"order_amount,omitempty"`
 	Documents             []Document        `json:"documents,omitempty"`
 	OrderLines            []OrderLine       `json:"order_lines,omitempty"`
 	ShippingAddress       ShippingAddress   `json:"shipping_address,omitempty"`
@@ -27,17 +27,19 @@ type Order struct {
 	Status                string            `json:"status,omitempty"`
 }
 type Document struct {
-	ShippingLabel bool   `json:"shipping_label,omitempty"`
-	Title         string `json:"title,omitempty"`
-	Quantity      int    `json:"quantity,omitempty"`
-	OrderPrice    int    `json:"orderPrice,omitempty"`
-	File          string `json:"file,omitempty"`
+	ID            string     `json:"id,omitempty"`
+	ShippingLabel bool       `json:"shipping_label,omitempty"`
+	Title         string     `json:"title,omitempty"`
+	Quantity      int        `json:"quantity,omitempty"`
+	OrderPrice    MinorUnits `json:"orderPrice,omitempty"`
+	File          string     `json:"file,omitempty"`
 }
 type OrderLine struct {
-	Price       float64 `json:"price,omitempty"`
-	Quantity    int     `json:"quantity,omitempty"`
-	Description string  `json:"description,omitempty"`
-	ArticleCode string  `json:"article_code,omitempty"`
+	Price       MajorUnits `json:"price,omitempty"`
+	Quantity    int        `json:"quantity,omitempty"`
+	Description string     `json:"description,omitempty"`
+	ArticleCode string     `json:"article_code,omitempty"`
+	Variant     *Variant   `json:"variant,omitempty"`
 }
 type ShippingAddress struct {
 	City                 string `json:"city,omitempty"`
@@ -54,6 +56,50 @@ type ShippingAddress struct {
 	StreetNumberAddition string `json:"street_number_addition,omitempty"`
 }
 
+// OrderUpdate holds the fields to change with OrdersService.Patch. Only the
+// fields that are set are sent, so fields can be cleared with a zero value or
+// Null.
+type OrderUpdate struct {
+	ExternalReference     Nullable[string]                `json:"external_reference,omitempty"`
+	ShippingContactperson Nullable[string]                `json:"shipping_contactperson,omitempty"`
+	RequestedDeliveryDate Nullable[string]                `json:"requested_delivery_date,omitempty"`
+	CustomerNote          Nullable[string]                `json:"customer_note,omitempty"`
+	ShippingEmail         Nullable[string]                `json:"shipping_email,omitempty"`
+	ShippingMethod        Nullable[string]                `json:"shipping_method,omitempty"`
+	Note                  Nullable[string]                `json:"note,omitempty"`
+	Currency              Nullable[string]                `json:"currency,omitempty"`
+	OrderAmount           Nullable[MinorUnits]            `json:"order_amount,omitempty"`
+	OrderLines            Nullable[[]OrderLineUpdate]     `json:"order_lines,omitempty"`
+	ShippingAddress       Nullable[ShippingAddressUpdate] `json:"shipping_address,omitempty"`
+	MetaData              Nullable[map[string]string]     `json:"meta_data,omitempty"`
+}
+
+// OrderLineUpdate is an order line in an OrderUpdate. Only the fields that
+// are set are sent.
+type OrderLineUpdate struct {
+	Price       Nullable[MajorUnits] `json:"price,omitempty"`
+	Quantity    Nullable[int]        `json:"quantity,omitempty"`
+	Description Nullable[string]     `json:"description,omitempty"`
+	ArticleCode Nullable[string]     `json:"article_code,omitempty"`
+}
+
+// ShippingAddressUpdate is the shipping address in an OrderUpdate. Only the
+// fields that are set are sent, so for example Street2 can be cleared.
+type ShippingAddressUpdate struct {
+	City                 Nullable[string] `json:"city,omitempty"`
+	State                Nullable[string] `json:"state,omitempty"`
+	Street               Nullable[string] `json:"street,omitempty"`
+	Country              Nullable[string] `json:"country,omitempty"`
+	Street2              Nullable[string] `json:"street2,omitempty"`
+	Zipcode              Nullable[string] `json:"zipcode,omitempty"`
+	FaxNumber            Nullable[string] `json:"fax_number,omitempty"`
+	AddressedTo          Nullable[string] `json:"addressed_to,omitempty"`
+	PhoneNumber          Nullable[string] `json:"phone_number,omitempty"`
+	MobileNumber         Nullable[string] `json:"mobile_number,omitempty"`
+	StreetNumber         Nullable[string] `json:"street_number,omitempty"`
+	StreetNumberAddition Nullable[string] `json:"street_number_addition,omitempty"`
+}
+
 type OrderListOptions struct {
 	Reference         string `url:"reference,omitempty"`
 	Status            string `url:"status,omitempty"`
@@ -97,10 +143,40 @@ func (os *OrdersService) Get(ctx context.Context, orderID string) (order *Order,
 	return
 }
 
+// FindByExternalReference returns the order with the given external
+// reference, or nil when there is none.
+func (os *OrdersService) FindByExternalReference(ctx context.Context, reference string) (order *Order, res *Response, err error) {
+	list, res, err := os.List(ctx, &OrderListOptions{ExternalReference: reference})
+	if err != nil || list == nil {
+		return
+	}
+
+	for i := range *list {
+		if (*list)[i].ExternalReference == reference {
+			return &(*list)[i], res, nil
+		}
+	}
+
+	return
+}
+
+// Create creates an order. When the outcome of the request is ambiguous, for
+// example after a timeout, the order is looked up by its external reference
+// before it is created again. It is not created again when the lookup fails
+// or the order has no external reference.
 func (os *OrdersService) Create(ctx context.Context, ord Order) (order *Order, res *Response, err error) {
-	res, err = os.client.post(ctx, "wms/orders/", ord, nil)
+	var lookup func(ctx context.Context) (bool, *Response, error)
+
+	if ord.ExternalReference != "" {
+		lookup = func(ctx context.Context) (bool, *Response, error) {
+			existing, res, err := os.FindByExternalReference(ctx, ord.ExternalReference)
+			order = existing
+			return existing != nil, res, err
+		}
+	}
 
-	if err != nil {
+	res, found, err := os.client.createWithGuard(ctx, "wms/orders/", ord, lookup)
+	if err != nil || found {
 		return
 	}
 
@@ -125,6 +201,20 @@ func (os *OrdersService) Update(ctx context.Context, orderID string, ord Order)
 	return
 }
 
+// Patch changes the fields set in upd and leaves all other fields untouched.
+func (os *OrdersService) Patch(ctx context.Context, orderID string, upd OrderUpdate) (order *Order, res *Response, err error) {
+	res, err = os.client.patch(ctx, fmt.Sprintf("wms/orders/%s/", orderID), upd, nil)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &order); err != nil {
+		return
+	}
+
+	return
+}
+
 func (os *OrdersService) Cancel(ctx context.Context, orderID string) (res *Response, err error) {
 	res, err = os.client.patch(ctx, fmt.Sprintf("wms/orders/%s/cancel/", orderID), nil, nil)
 	if err != nil {
@@ -0,0 +1,462 @@
+package ewhs
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const defaultImportConcurrency int = 4
+
+var (
+	errMissingExternalReference = errors.New("missing external reference")
+	errInvalidQuantity          = errors.New("invalid quantity")
+)
+
+type ImportStatus string
+
+const (
+	ImportCreated   ImportStatus = "created"
+	ImportDuplicate ImportStatus = "duplicate"
+	ImportInvalid   ImportStatus = "invalid"
+	ImportFailed    ImportStatus = "failed"
+)
+
+// ImportMapping maps column headers to order fields. Fields are named after
+// their JSON names, with a prefix for nested fields:
+//
+//	"external_reference", "shipping_email", ...
+//	"shipping_address.zipcode", "shipping_address.country", ...
+//	"order_lines.article_code", "order_lines.quantity", "order_lines.price", ...
+//	"meta_data.<key>"
+//
+// Amounts in order_amount and order_lines.price are decimal numbers of major
+// units, e.g. 12.95. Order amounts are rounded to the currency of the order;
+// prices keep all their decimal places.
+type ImportMapping map[string]string
+
+// ImportOptions configures OrdersService.ImportCSV and ImportJSONL.
+type ImportOptions struct {
+	// Mapping maps CSV column headers to order fields. Columns named after
+	// an order field are mapped to it without an entry.
+	Mapping ImportMapping
+	// Comma is the CSV field delimiter, a comma by default.
+	Comma rune
+	// Concurrency is the number of orders created in parallel.
+	Concurrency int
+	// NormalizeAddress passes shipping addresses through NormalizeAddress.
+	NormalizeAddress bool
+}
+
+// ImportResult is the outcome of importing one order. Rows holds the CSV
+// rows or JSONL lines the order was read from, starting at 1.
+type ImportResult struct {
+	Rows              []int
+	ExternalReference string
+	Status            ImportStatus
+	OrderID           string
+	Err               error
+}
+
+// ImportReport holds the results of an import in the order of the input.
+type ImportReport struct {
+	Results []ImportResult
+}
+
+// Count returns the number of orders with the given status.
+func (r *ImportReport) Count(status ImportStatus) int {
+	n := 0
+
+	for _, res := range r.Results {
+		if res.Status == status {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Row returns the result of the order read from the given row, or nil.
+func (r *ImportReport) Row(row int) *ImportResult {
+	for i := range r.Results {
+		for _, n := range r.Results[i].Rows {
+			if n == row {
+				return &r.Results[i]
+			}
+		}
+	}
+
+	return nil
+}
+
+type importOrder struct {
+	rows  []int
+	order Order
+	err   error
+}
+
+// ImportCSV creates the orders in a CSV file with a header row and one row
+// per order line. Rows are grouped into orders by external reference; order
+// fields are taken from the first row that has them. Orders are validated
+// and created concurrently. Orders that already exist are skipped.
+func (os *OrdersService) ImportCSV(ctx context.Context, r io.Reader, opts *ImportOptions) (*ImportReport, error) {
+	var o ImportOptions
+	if opts != nil {
+		o = *opts
+	}
+
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	if o.Comma != 0 {
+		cr.Comma = o.Comma
+	}
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]string, len(header))
+	for i, h := range header {
+		h = strings.TrimSpace(strings.TrimPrefix(h, "\ufeff"))
+
+		fields[i] = h
+		if f, ok := o.Mapping[h]; ok {
+			fields[i] = f
+		}
+	}
+
+	var orders []*importOrder
+	index := map[string]*importOrder{}
+
+	for row := 2; ; row++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		values := map[string]string{}
+		for i, v := range record {
+			if i < len(fields) && fields[i] != "" {
+				values[fields[i]] = strings.TrimSpace(v)
+			}
+		}
+
+		ref := values["external_reference"]
+
+		imp, ok := index[ref]
+		if !ok || ref == "" {
+			imp = &importOrder{}
+			orders = append(orders, imp)
+			index[ref] = imp
+		}
+
+		imp.rows = append(imp.rows, row)
+
+		if imp.err == nil {
+			imp.err = applyImportRow(&imp.order, values)
+		}
+	}
+
+	return os.importOrders(ctx, orders, o), nil
+}
+
+// ImportJSONL creates the orders in a JSON Lines file with one Order per
+// line. Orders are validated and created concurrently. Orders that already
+// exist or appear twice are skipped.
+func (os *OrdersService) ImportJSONL(ctx context.Context, r io.Reader, opts *ImportOptions) (*ImportReport, error) {
+	var o ImportOptions
+	if opts != nil {
+		o = *opts
+	}
+
+	var orders []*importOrder
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 10*1024*1024)
+
+	for line := 1; sc.Scan(); line++ {
+		b := strings.TrimSpace(sc.Text())
+		if b == "" {
+			continue
+		}
+
+		imp := &importOrder{rows: []int{line}}
+		imp.err = json.Unmarshal([]byte(b), &imp.order)
+		orders = append(orders, imp)
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	return os.importOrders(ctx, orders, o), nil
+}
+
+func (os *OrdersService) importOrders(ctx context.Context, orders []*importOrder, opts ImportOptions) *ImportReport {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultImportConcurrency
+	}
+
+	report := &ImportReport{Results: make([]ImportResult, len(orders))}
+	seen := map[string]bool{}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+
+	for i, imp := range orders {
+		res := &report.Results[i]
+		res.Rows = imp.rows
+		res.ExternalReference = imp.order.ExternalReference
+
+		if opts.NormalizeAddress {
+			imp.order.ShippingAddress = NormalizeAddress(imp.order.ShippingAddress)
+		}
+
+		switch {
+		case imp.err != nil:
+			res.Status, res.Err = ImportInvalid, imp.err
+			continue
+		case imp.order.ExternalReference == "":
+			res.Status, res.Err = ImportInvalid, errMissingExternalReference
+			continue
+		case seen[imp.order.ExternalReference]:
+			res.Status = ImportDuplicate
+			continue
+		}
+
+		seen[imp.order.ExternalReference] = true
+
+		if err := imp.order.Validate(); err != nil {
+			res.Status, res.Err = ImportInvalid, err
+			continue
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			res.Status, res.Err = ImportFailed, ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(order Order) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			os.importOrder(ctx, order, res)
+		}(imp.order)
+	}
+
+	wg.Wait()
+
+	return report
+}
+
+func (os *OrdersService) importOrder(ctx context.Context, order Order, res *ImportResult) {
+	existing, _, err := os.FindByExternalReference(ctx, order.ExternalReference)
+	if err != nil {
+		res.Status, res.Err = ImportFailed, err
+		return
+	}
+
+	if existing != nil {
+		res.Status, res.OrderID = ImportDuplicate, existing.ID
+		return
+	}
+
+	created, _, err := os.Create(ctx, order)
+	if err != nil {
+		res.Status, res.Err = ImportFailed, err
+		return
+	}
+
+	res.Status = ImportCreated
+	if created != nil {
+		res.OrderID = created.ID
+	}
+}
+
+// applyImportRow sets the fields of a CSV row on an order. The order line
+// fields of a row add an order line.
+func applyImportRow(o *Order, values map[string]string) error {
+	var line OrderLine
+	hasLine := false
+
+	// the order amount is parsed in the currency of the order, whichever
+	// column comes first
+	currency := o.Currency
+	if currency == "" {
+		currency = values["currency"]
+	}
+
+	for field, v := range values {
+		if v == "" {
+			continue
+		}
+
+		if key, ok := strings.CutPrefix(field, "meta_data."); ok {
+			if o.MetaData == nil {
+				o.MetaData = map[string]string{}
+			}
+
+			if _, set := o.MetaData[key]; !set {
+				o.MetaData[key] = v
+			}
+
+			continue
+		}
+
+		if f, ok := strings.CutPrefix(field, "order_lines."); ok {
+			hasLine = true
+
+			if err := setOrderLineField(&line, f, v); err != nil {
+				return fmt.Errorf("%s: %w", field, err)
+			}
+
+			continue
+		}
+
+		if f, ok := strings.CutPrefix(field, "shipping_address."); ok {
+			setFirst(shippingAddressField(&o.ShippingAddress, f), v)
+			continue
+		}
+
+		if field == "order_amount" {
+			if o.OrderAmount == 0 {
+				m, err := parseImportAmount(v, currency)
+				if err != nil {
+					return fmt.Errorf("%s: %w", field, err)
+				}
+
+				o.OrderAmount = m.MinorUnits()
+			}
+
+			continue
+		}
+
+		setFirst(orderField(o, field), v)
+	}
+
+	if hasLine {
+		o.OrderLines = append(o.OrderLines, line)
+	}
+
+	return nil
+}
+
+func setFirst(p *string, v string) {
+	if p != nil && *p == "" {
+		*p = v
+	}
+}
+
+func orderField(o *Order, field string) *string {
+	switch field {
+	case "external_reference":
+		return &o.ExternalReference
+	case "external_id":
+		return &o.ExternalID
+	case "shipping_contactperson":
+		return &o.ShippingContactperson
+	case "requested_delivery_date":
+		return &o.RequestedDeliveryDate
+	case "customer_note":
+		return &o.CustomerNote
+	case "shipping_email":
+		return &o.ShippingEmail
+	case "shipping_method":
+		return &o.ShippingMethod
+	case "note":
+		return &o.Note
+	case "currency":
+		return &o.Currency
+	}
+
+	return nil
+}
+
+func shippingAddressField(a *ShippingAddress, field string) *string {
+	switch field {
+	case "addressed_to":
+		return &a.AddressedTo
+	case "street":
+		return &a.Street
+	case "street2":
+		return &a.Street2
+	case "street_number":
+		return &a.StreetNumber
+	case "street_number_addition":
+		return &a.StreetNumberAddition
+	case "zipcode":
+		return &a.Zipcode
+	case "city":
+		return &a.City
+	case "state":
+		return &a.State
+	case "country":
+		return &a.Country
+	case "phone_number":
+		return &a.PhoneNumber
+	case "mobile_number":
+		return &a.MobileNumber
+	case "fax_number":
+		return &a.FaxNumber
+	}
+
+	return nil
+}
+
+func setOrderLineField(l *OrderLine, field string, v string) error {
+	switch field {
+	case "article_code":
+		l.ArticleCode = v
+	case "description":
+		l.Description = v
+	case "quantity":
+		q, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("%w: %w", errInvalidQuantity, err)
+		}
+
+		l.Quantity = q
+	case "price":
+		p, err := ParseMajorUnits(importDecimal(v))
+		if err != nil {
+			return err
+		}
+
+		l.Price = p
+	}
+
+	return nil
+}
+
+// parseImportAmount parses an amount in major units, rounded to the decimal
+// places of the currency.
+func parseImportAmount(v string, currency string) (Money, error) {
+	return ParseMoney(importDecimal(v), currency)
+}
+
+// importDecimal returns a decimal number, which spreadsheets may write with a
+// decimal comma, with a decimal point.
+func importDecimal(v string) string {
+	return strings.Replace(v, ",", ".", 1)
+}
@@ -0,0 +1,207 @@
+package ewhs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// importServer serves an order list in which "existing" already exists and
+// records the orders that were created.
+func importServer(t *testing.T) *[]Order {
+	t.Helper()
+
+	var mu sync.Mutex
+	created := []Order{}
+
+	tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+	tMux.HandleFunc("/wms/orders/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			if r.URL.Query().Get("external_reference") == "existing" {
+				_, _ = w.Write([]byte(`[{"id":"c9165f93-8301-4aaa-9f64-27f191c0c778","external_reference":"existing"}]`))
+				return
+			}
+
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+
+		testMethod(t, r, http.MethodPost)
+
+		var o Order
+		if err := json.NewDecoder(r.Body).Decode(&o); err != nil {
+			t.Fatal(err)
+		}
+
+		mu.Lock()
+		created = append(created, o)
+		o.ID = fmt.Sprintf("created-%s", o.ExternalReference)
+		mu.Unlock()
+
+		b, _ := json.Marshal(o)
+		_, _ = w.Write(b)
+	})
+
+	return &created
+}
+
+func TestApplyImportRow_Currency(t *testing.T) {
+	var o Order
+	assert.Nil(t, applyImportRow(&o, map[string]string{
+		"external_reference":       "1001",
+		"order_amount":             "2,470",
+		"currency":                 "KWD",
+		"order_lines.article_code": "green_jacket",
+		"order_lines.quantity":     "2",
+		"order_lines.price":        "1.235",
+	}))
+
+	assert.Equal(t, MinorUnits(2470), o.OrderAmount)
+	assert.Equal(t, []OrderLine{{ArticleCode: "green_jacket", Quantity: 2, Price: "1.235"}}, o.OrderLines)
+}
+
+func TestApplyImportRow_PricePrecision(t *testing.T) {
+	var o Order
+	assert.Nil(t, applyImportRow(&o, map[string]string{
+		"currency":                 "EUR",
+		"order_lines.article_code": "green_jacket",
+		"order_lines.quantity":     "100",
+		"order_lines.price":        "0,125",
+	}))
+
+	assert.Equal(t, []OrderLine{{ArticleCode: "green_jacket", Quantity: 100, Price: "0.125"}}, o.OrderLines)
+
+	total, err := o.LinesTotal()
+	assert.Nil(t, err)
+	assert.Equal(t, NewMoney(1250, "EUR"), total)
+}
+
+func TestOrdersService_ImportCSV(t *testing.T) {
+	setup()
+	defer teardown()
+
+	created := importServer(t)
+
+	csv := "\ufeffOrder;Name;Street;Zip;City;Country;E-mail;SKU;Qty;Price;Channel\n" +
+		"1001;John Doe;Nijverheidsweg 27a;3331 mb;Heinenoord;Netherlands;john.doe@example.com;green_jacket;3;12,95;shop\n" +
+		"1001;;;;;;;red_jacket;1;24.50;\n" +
+		"1002;Jane Doe;Rue de la Loi 16;1000;Brussel;BE;jane@example.com;green_jacket;0;12.95;shop\n" +
+		"existing;Max;Hauptstraße 1;10115;Berlin;DE;max@example.com;green_jacket;1;12.95;shop\n" +
+		"1003;Anna;Kerkstraat 1;1017GA;Amsterdam;NL;anna@example.com;green_jacket;two;12.95;shop\n"
+
+	report, err := tClient.Orders.ImportCSV(context.Background(), strings.NewReader(csv), &ImportOptions{
+		Comma: ';',
+		Mapping: ImportMapping{
+			"Order":   "external_reference",
+			"Name":    "shipping_address.addressed_to",
+			"Street":  "shipping_address.street",
+			"Zip":     "shipping_address.zipcode",
+			"City":    "shipping_address.city",
+			"Country": "shipping_address.country",
+			"E-mail":  "shipping_email",
+			"SKU":     "order_lines.article_code",
+			"Qty":     "order_lines.quantity",
+			"Price":   "order_lines.price",
+			"Channel": "meta_data.channel",
+		},
+		Concurrency:      2,
+		NormalizeAddress: true,
+	})
+	assert.Nil(t, err)
+
+	assert.Len(t, report.Results, 4)
+	assert.Equal(t, 1, report.Count(ImportCreated))
+	assert.Equal(t, 1, report.Count(ImportDuplicate))
+	assert.Equal(t, 2, report.Count(ImportInvalid))
+
+	first := report.Row(3)
+	assert.Equal(t, []int{2, 3}, first.Rows)
+	assert.Equal(t, ImportCreated, first.Status)
+	assert.Equal(t, "created-1001", first.OrderID)
+
+	invalid := report.Row(4)
+	assert.Equal(t, ImportInvalid, invalid.Status)
+	assert.Equal(t, []string{"order_lines[0].quantity"}, violationPaths(t, invalid.Err))
+
+	existing := report.Row(5)
+	assert.Equal(t, ImportDuplicate, existing.Status)
+	assert.Equal(t, "c9165f93-8301-4aaa-9f64-27f191c0c778", existing.OrderID)
+
+	unparsable := report.Row(6)
+	assert.Equal(t, ImportInvalid, unparsable.Status)
+	assert.True(t, errors.Is(unparsable.Err, errInvalidQuantity))
+
+	var numErr *strconv.NumError
+	assert.True(t, errors.As(unparsable.Err, &numErr))
+
+	assert.Nil(t, report.Row(7))
+
+	if assert.Len(t, *created, 1) {
+		o := (*created)[0]
+		assert.Equal(t, "1001", o.ExternalReference)
+		assert.Equal(t, map[string]string{"channel": "shop"}, o.MetaData)
+		assert.Equal(t, ShippingAddress{
+			AddressedTo:          "John Doe",
+			Street:               "Nijverheidsweg",
+			StreetNumber:         "27",
+			StreetNumberAddition: "A",
+			Zipcode:              "3331MB",
+			City:                 "Heinenoord",
+			Country:              "NL",
+		}, o.ShippingAddress)
+		assert.Equal(t, []OrderLine{
+			{ArticleCode: "green_jacket", Quantity: 3, Price: "12.95"},
+			{ArticleCode: "red_jacket", Quantity: 1, Price: "24.50"},
+		}, o.OrderLines)
+	}
+}
+
+func TestOrdersService_ImportJSONL(t *testing.T) {
+	setup()
+	defer teardown()
+
+	created := importServer(t)
+
+	order := func(ref string) string {
+		o := validOrder()
+		o.ExternalReference = ref
+
+		b, _ := json.Marshal(o)
+
+		return string(b)
+	}
+
+	jsonl := strings.Join([]string{
+		order("2001"),
+		"",
+		order("2002"),
+		order("2001"),
+		`{"external_reference":`,
+		order("existing"),
+	}, "\n")
+
+	report, err := tClient.Orders.ImportJSONL(context.Background(), strings.NewReader(jsonl), nil)
+	assert.Nil(t, err)
+
+	assert.Len(t, report.Results, 5)
+	assert.Equal(t, 2, report.Count(ImportCreated))
+	assert.Equal(t, 2, report.Count(ImportDuplicate))
+	assert.Equal(t, 1, report.Count(ImportInvalid))
+
+	assert.Equal(t, ImportCreated, report.Row(1).Status)
+	assert.Equal(t, ImportCreated, report.Row(3).Status)
+	assert.Equal(t, ImportDuplicate, report.Row(4).Status)
+	assert.Equal(t, ImportInvalid, report.Row(5).Status)
+	assert.Equal(t, ImportDuplicate, report.Row(6).Status)
+	assert.Nil(t, report.Row(2))
+
+	assert.Len(t, *created, 2)
+}
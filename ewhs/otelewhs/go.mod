@@ -0,0 +1,30 @@
+module github.com/ewarehousing-solutions/ewhs-api-go/ewhs/otelewhs
+
+go 1.21
+
+require (
+	github.com/ewarehousing-solutions/ewhs-api-go v1.1.0
+	github.com/stretchr/testify v1.9.0
+	go.opentelemetry.io/otel v1.28.0
+	go.opentelemetry.io/otel/metric v1.28.0
+	go.opentelemetry.io/otel/sdk v1.28.0
+	go.opentelemetry.io/otel/sdk/metric v1.28.0
+	go.opentelemetry.io/otel/trace v1.28.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/go-querystring v1.1.0 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/sys v0.21.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// v1.1.0 is the first release with the middleware API. The replace only
+// applies when building this module inside the repository, so changes to the
+// core module can be tested before they are tagged; it is ignored for modules
+// that depend on otelewhs.
+replace github.com/ewarehousing-solutions/ewhs-api-go => ../..
@@ -0,0 +1,292 @@
+// Package otelewhs instruments an ewhs.Client with OpenTelemetry spans and
+// metrics. It hooks into the client's middleware chain, so none of the
+// service calls need to change.
+package otelewhs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ewarehousing-solutions/ewhs-api-go/ewhs"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// MiddlewareName is the name the instrumentation is registered under.
+	MiddlewareName string = "otel"
+
+	instrumentationName string = "github.com/ewarehousing-solutions/ewhs-api-go/ewhs/otelewhs"
+)
+
+// Attribute keys set on spans and metrics.
+const (
+	OperationKey    = attribute.Key("ewhs.operation")
+	CustomerCodeKey = attribute.Key("ewhs.customer_code")
+	WmsCodeKey      = attribute.Key("ewhs.wms_code")
+	ResourceIDKey   = attribute.Key("ewhs.resource_id")
+	MethodKey       = attribute.Key("http.request.method")
+	StatusCodeKey   = attribute.Key("http.response.status_code")
+	ErrorTypeKey    = attribute.Key("error.type")
+)
+
+// services maps the first path segment of an endpoint to its service name.
+var services = map[string]string{
+	"articles":        "Articles",
+	"auth":            "Auth",
+	"batches":         "Batches",
+	"customers":       "Customers",
+	"exports":         "Exports",
+	"gdpr":            "Gdpr",
+	"inbounds":        "Inbounds",
+	"modifications":   "Modifications",
+	"orders":          "Orders",
+	"shipments":       "Shipments",
+	"shippingmethods": "ShippingMethods",
+	"stock":           "Stock",
+	"variants":        "Variants",
+	"webhooks":        "Webhooks",
+}
+
+// actionServices are services whose second segment is an action, not an ID.
+var actionServices = map[string]bool{
+	"auth": true,
+	"gdpr": true,
+}
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagator     propagation.TextMapPropagator
+}
+
+// Option configures the instrumentation.
+type Option func(*config)
+
+// WithTracerProvider sets the tracer provider, the global provider is used by default.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithMeterProvider sets the meter provider, the global provider is used by default.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = mp
+	}
+}
+
+// WithPropagator sets the propagator used to inject the trace context into
+// outgoing requests, the global propagator is used by default.
+func WithPropagator(p propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagator = p
+	}
+}
+
+type instruments struct {
+	tracer         trace.Tracer
+	propagator     propagation.TextMapPropagator
+	requests       metric.Int64Counter
+	duration       metric.Float64Histogram
+	errors         metric.Int64Counter
+	tokenRefreshes metric.Int64Counter
+}
+
+// Instrument registers the instrumentation as the outermost middleware of the
+// client, so a single span covers all retries of an API call.
+func Instrument(c *ewhs.Client, opts ...Option) error {
+	mw, err := Middleware(opts...)
+	if err != nil {
+		return err
+	}
+
+	names := c.Middlewares()
+	if len(names) == 0 {
+		return c.Use(MiddlewareName, mw)
+	}
+
+	return c.UseBefore(names[0], MiddlewareName, mw)
+}
+
+// Middleware returns the instrumentation as an ewhs.Middleware, for callers
+// that want to position it in the chain themselves.
+func Middleware(opts ...Option) (ewhs.Middleware, error) {
+	cfg := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagator:     otel.GetTextMapPropagator(),
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	meter := cfg.meterProvider.Meter(instrumentationName, metric.WithInstrumentationVersion(ewhs.Version))
+
+	in := instruments{
+		tracer:     cfg.tracerProvider.Tracer(instrumentationName, trace.WithInstrumentationVersion(ewhs.Version)),
+		propagator: cfg.propagator,
+	}
+
+	var err error
+
+	if in.requests, err = meter.Int64Counter("ewhs.client.requests",
+		metric.WithDescription("Number of API calls."),
+		metric.WithUnit("{request}")); err != nil {
+		return nil, err
+	}
+
+	if in.duration, err = meter.Float64Histogram("ewhs.client.duration",
+		metric.WithDescription("Duration of API calls, including retries."),
+		metric.WithUnit("s")); err != nil {
+		return nil, err
+	}
+
+	if in.errors, err = meter.Int64Counter("ewhs.client.errors",
+		metric.WithDescription("Number of failed API calls by error type."),
+		metric.WithUnit("{error}")); err != nil {
+		return nil, err
+	}
+
+	if in.tokenRefreshes, err = meter.Int64Counter("ewhs.client.token_refreshes",
+		metric.WithDescription("Number of requested access tokens."),
+		metric.WithUnit("{token}")); err != nil {
+		return nil, err
+	}
+
+	return in.middleware, nil
+}
+
+func (in instruments) middleware(next ewhs.RoundTrip) ewhs.RoundTrip {
+	return func(req *http.Request) (*ewhs.Response, error) {
+		op, resourceID := Operation(req)
+
+		tenant := []attribute.KeyValue{
+			CustomerCodeKey.String(req.Header.Get(ewhs.CustomerCodeHeader)),
+			WmsCodeKey.String(req.Header.Get(ewhs.WmsCodeHeader)),
+		}
+
+		attrs := append([]attribute.KeyValue{
+			OperationKey.String(op),
+			MethodKey.String(req.Method),
+		}, tenant...)
+
+		ctx, span := in.tracer.Start(req.Context(), op,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attrs...))
+		defer span.End()
+
+		if resourceID != "" {
+			span.SetAttributes(ResourceIDKey.String(resourceID))
+		}
+
+		req = req.WithContext(ctx)
+		in.propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+		start := time.Now()
+		res, err := next(req)
+		elapsed := time.Since(start).Seconds()
+
+		if res != nil {
+			status := StatusCodeKey.Int(res.StatusCode)
+			attrs = append(attrs, status)
+			span.SetAttributes(status)
+		}
+
+		if err != nil {
+			errType := errorType(res, err)
+			attrs = append(attrs, ErrorTypeKey.String(errType))
+
+			span.SetAttributes(ErrorTypeKey.String(errType))
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+
+			in.errors.Add(ctx, 1, metric.WithAttributes(attrs...))
+		}
+
+		set := metric.WithAttributes(attrs...)
+		in.requests.Add(ctx, 1, set)
+		in.duration.Record(ctx, elapsed, set)
+
+		if op == "Auth.Login" && err == nil {
+			in.tokenRefreshes.Add(ctx, 1, metric.WithAttributes(tenant...))
+		}
+
+		return res, err
+	}
+}
+
+// Operation derives the service and operation name of a request, such as
+// "Orders.Create" or "Shipments.Get", and the ID of the resource it targets.
+func Operation(req *http.Request) (op string, resourceID string) {
+	segments := strings.FieldsFunc(req.URL.Path, func(r rune) bool { return r == '/' })
+
+	for i, s := range segments {
+		name, ok := services[s]
+		if !ok {
+			continue
+		}
+
+		rest := segments[i+1:]
+
+		if actionServices[s] {
+			if len(rest) == 0 {
+				return name, ""
+			}
+
+			return name + "." + camelCase(rest[0]), ""
+		}
+
+		switch {
+		case len(rest) == 0 && req.Method == http.MethodPost:
+			return name + ".Create", ""
+		case len(rest) == 0:
+			return name + ".List", ""
+		case len(rest) == 1 && (req.Method == http.MethodPatch || req.Method == http.MethodPut):
+			return name + ".Update", rest[0]
+		case len(rest) == 1 && req.Method == http.MethodDelete:
+			return name + ".Delete", rest[0]
+		case len(rest) == 1:
+			return name + ".Get", rest[0]
+		default:
+			return name + "." + camelCase(rest[1]), rest[0]
+		}
+	}
+
+	return req.Method + " " + req.URL.Path, ""
+}
+
+func camelCase(s string) string {
+	parts := strings.FieldsFunc(s, func(r rune) bool { return r == '-' || r == '_' })
+
+	for i, p := range parts {
+		parts[i] = strings.ToUpper(p[:1]) + p[1:]
+	}
+
+	return strings.Join(parts, "")
+}
+
+func errorType(res *ewhs.Response, err error) string {
+	switch {
+	case res != nil && res.StatusCode >= http.StatusBadRequest:
+		return strconv.Itoa(res.StatusCode)
+	case errors.Is(err, ewhs.ErrCircuitOpen):
+		return "circuit_open"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	default:
+		return "transport"
+	}
+}
@@ -0,0 +1,124 @@
+package otelewhs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/ewarehousing-solutions/ewhs-api-go/ewhs"
+	"github.com/ewarehousing-solutions/ewhs-api-go/test/testdata"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestOperation(t *testing.T) {
+	tests := []struct {
+		method     string
+		path       string
+		op         string
+		resourceID string
+	}{
+		{http.MethodGet, "/wms/orders/", "Orders.List", ""},
+		{http.MethodPost, "/wms/orders/", "Orders.Create", ""},
+		{http.MethodGet, "/wms/orders/c9165f93/", "Orders.Get", "c9165f93"},
+		{http.MethodPatch, "/wms/orders/c9165f93/", "Orders.Update", "c9165f93"},
+		{http.MethodPatch, "/wms/orders/c9165f93/cancel/", "Orders.Cancel", "c9165f93"},
+		{http.MethodDelete, "/webhooks/c9165f93/", "Webhooks.Delete", "c9165f93"},
+		{http.MethodGet, "/wms/shippingmethods/", "ShippingMethods.List", ""},
+		{http.MethodPost, "/wms/gdpr/request-person-data/", "Gdpr.RequestPersonData", ""},
+		{http.MethodPost, "/wms/auth/login/", "Auth.Login", ""},
+		{http.MethodGet, "/wms/unknown/", "GET /wms/unknown/", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.op, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+
+			op, id := Operation(req)
+			assert.Equal(t, tt.op, op)
+			assert.Equal(t, tt.resourceID, id)
+		})
+	}
+}
+
+func TestInstrument(t *testing.T) {
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	mux.HandleFunc("/wms/auth/login/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testdata.CreateAuthTokenResponse))
+	})
+	mux.HandleFunc("/wms/orders/c9165f93-8301-4aaa-9f64-27f191c0c778/", func(w http.ResponseWriter, r *http.Request) {
+		assert.NotEmpty(t, r.Header.Get("Traceparent"))
+		_, _ = w.Write([]byte(testdata.GetOrderResponse))
+	})
+	mux.HandleFunc("/wms/orders/missing/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	client, _ := ewhs.NewClient(nil, ewhs.NewConfig("test_username", "test_password", "test_wms", "test_customer", true))
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+
+	err := Instrument(client,
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+		WithPropagator(propagation.TraceContext{}))
+	assert.Nil(t, err)
+	assert.Equal(t, MiddlewareName, client.Middlewares()[0])
+
+	_, _, err = client.Orders.Get(context.Background(), "c9165f93-8301-4aaa-9f64-27f191c0c778")
+	assert.Nil(t, err)
+
+	_, _, err = client.Orders.Get(context.Background(), "missing")
+	assert.NotNil(t, err)
+
+	ended := spans.Ended()
+	assert.Len(t, ended, 3)
+
+	names := []string{}
+	for _, s := range ended {
+		names = append(names, s.Name())
+	}
+	assert.Equal(t, []string{"Auth.Login", "Orders.Get", "Orders.Get"}, names)
+
+	assert.Equal(t, ended[1].SpanContext().SpanID(), ended[0].Parent().SpanID())
+	assert.Contains(t, ended[1].Attributes(), ResourceIDKey.String("c9165f93-8301-4aaa-9f64-27f191c0c778"))
+	assert.Contains(t, ended[1].Attributes(), CustomerCodeKey.String("test_customer"))
+	assert.Contains(t, ended[1].Attributes(), StatusCodeKey.Int(http.StatusOK))
+	assert.Equal(t, codes.Error, ended[2].Status().Code)
+
+	var rm metricdata.ResourceMetrics
+	assert.Nil(t, reader.Collect(context.Background(), &rm))
+
+	sums := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if data, ok := m.Data.(metricdata.Sum[int64]); ok {
+				for _, dp := range data.DataPoints {
+					sums[m.Name] += dp.Value
+
+					if m.Name == "ewhs.client.errors" {
+						v, _ := dp.Attributes.Value(ErrorTypeKey)
+						assert.Equal(t, attribute.StringValue("404"), v)
+					}
+				}
+			}
+		}
+	}
+
+	assert.Equal(t, int64(3), sums["ewhs.client.requests"])
+	assert.Equal(t, int64(1), sums["ewhs.client.errors"])
+	assert.Equal(t, int64(1), sums["ewhs.client.token_refreshes"])
+}
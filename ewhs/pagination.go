@@ -0,0 +1,30 @@
+package ewhs
+
+const defaultPageSize int = 100
+
+// listPages calls fetch for consecutive pages, starting at page 1, until a
+// page holds fewer than limit items, and returns the items of all pages.
+func listPages[T any](limit int, fetch func(page int, limit int) (*[]T, *Response, error)) ([]T, error) {
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+
+	var all []T
+
+	for page := 1; ; page++ {
+		list, _, err := fetch(page, limit)
+		if err != nil {
+			return all, err
+		}
+
+		if list == nil {
+			return all, nil
+		}
+
+		all = append(all, *list...)
+
+		if len(*list) < limit {
+			return all, nil
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package ewhs
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatch_SendsOnlySetFields(t *testing.T) {
+	tests := []struct {
+		name  string
+		path  string
+		patch func(ctx context.Context) error
+		want  string
+	}{
+		{
+			"order",
+			"/wms/orders/c9165f93-8301-4aaa-9f64-27f191c0c778/",
+			func(ctx context.Context) error {
+				_, _, err := tClient.Orders.Patch(ctx, "c9165f93-8301-4aaa-9f64-27f191c0c778", OrderUpdate{
+					Note:         NewNullable(""),
+					OrderAmount:  NewNullable[MinorUnits](0),
+					CustomerNote: Null[string](),
+				})
+				return err
+			},
+			`{"note":"","order_amount":0,"customer_note":null}`,
+		},
+		{
+			"order with nested fields",
+			"/wms/orders/c9165f93-8301-4aaa-9f64-27f191c0c778/",
+			func(ctx context.Context) error {
+				_, _, err := tClient.Orders.Patch(ctx, "c9165f93-8301-4aaa-9f64-27f191c0c778", OrderUpdate{
+					ShippingAddress: NewNullable(ShippingAddressUpdate{
+						Street2:              NewNullable(""),
+						StreetNumberAddition: Null[string](),
+					}),
+					OrderLines: NewNullable([]OrderLineUpdate{
+						{ArticleCode: NewNullable("cream"), Quantity: NewNullable(0), Description: NewNullable("")},
+					}),
+				})
+				return err
+			},
+			`{"shipping_address":{"street2":"","street_number_addition":null},` +
+				`"order_lines":[{"article_code":"cream","quantity":0,"description":""}]}`,
+		},
+		{
+			"variant",
+			"/wms/variants/1e19da60-4d2b-4c15-8f4e-8978f6113c00/",
+			func(ctx context.Context) error {
+				_, _, err := tClient.Variants.Patch(ctx, "1e19da60-4d2b-4c15-8f4e-8978f6113c00", VariantUpdate{
+					Expirable: NewNullable(false),
+					Value:     NewNullable(NewMajorUnits(0, 2)),
+				})
+				return err
+			},
+			`{"expirable":false,"value":0.00}`,
+		},
+		{
+			"article",
+			"/wms/articles/a1/",
+			func(ctx context.Context) error {
+				_, _, err := tClient.Articles.Patch(ctx, "a1", ArticleUpdate{Variants: NewNullable([]ArticleVariantUpdate{})})
+				return err
+			},
+			`{"variants":[]}`,
+		},
+		{
+			"article with variant fields",
+			"/wms/articles/a1/",
+			func(ctx context.Context) error {
+				_, _, err := tClient.Articles.Patch(ctx, "a1", ArticleUpdate{Variants: NewNullable([]ArticleVariantUpdate{
+					{ArticleCode: NewNullable("green_jacket"), Expirable: NewNullable(false), Description: NewNullable(""), Sku: Null[string]()},
+				})})
+				return err
+			},
+			`{"variants":[{"article_code":"green_jacket","expirable":false,"description":"","sku":null}]}`,
+		},
+		{
+			"inbound",
+			"/wms/inbounds/i1/",
+			func(ctx context.Context) error {
+				_, _, err := tClient.Inbounds.Patch(ctx, "i1", InboundUpdate{Note: NewNullable(""), Supplier: Null[SupplierUpdate]()})
+				return err
+			},
+			`{"note":"","supplier":null}`,
+		},
+		{
+			"inbound with line and supplier fields",
+			"/wms/inbounds/i1/",
+			func(ctx context.Context) error {
+				_, _, err := tClient.Inbounds.Patch(ctx, "i1", InboundUpdate{
+					Supplier: NewNullable(SupplierUpdate{Reference: NewNullable("")}),
+					InboundLines: NewNullable([]InboundLineUpdate{
+						{ArticleCode: NewNullable("cream"), Quantity: NewNullable(0), LotNumber: Null[string]()},
+					}),
+				})
+				return err
+			},
+			`{"supplier":{"reference":""},"inbound_lines":[{"article_code":"cream","quantity":0,"lot_number":null}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup()
+			defer teardown()
+
+			tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+
+			tMux.HandleFunc(tt.path, func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, "PATCH")
+
+				body, _ := io.ReadAll(r.Body)
+				assert.JSONEq(t, tt.want, string(body))
+
+				_, _ = w.Write([]byte(`{}`))
+			})
+
+			assert.Nil(t, tt.patch(context.Background()))
+		})
+	}
+}
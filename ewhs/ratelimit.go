@@ -0,0 +1,106 @@
+package ewhs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const MiddlewareRateLimit string = "rate_limit"
+
+var errInvalidRateLimit = errors.New("rate limit must be positive")
+
+// rateLimiter is a token bucket that holds up to burst requests and refills
+// at rate requests per second.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// WithRateLimit limits the client to rate requests per second with bursts of
+// up to burst requests. Requests wait for their turn until their context is
+// done. The limiter runs behind the retry middleware, so retries count too,
+// and is shared by all goroutines using the client, including batch calls.
+func (c *Client) WithRateLimit(rate float64, burst int) error {
+	if rate <= 0 {
+		return errInvalidRateLimit
+	}
+
+	if burst < 1 {
+		burst = 1
+	}
+
+	rl := &rateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		now:    time.Now,
+	}
+	rl.last = rl.now()
+
+	c.setMiddleware(MiddlewareRateLimit, rl.middleware, func() int {
+		if i := c.middlewareIndex(MiddlewareRetry); i >= 0 {
+			return i + 1
+		}
+
+		return len(c.middlewares)
+	})
+
+	c.middlewaresMu.Lock()
+	c.limiter = rl
+	c.middlewaresMu.Unlock()
+
+	return nil
+}
+
+func (rl *rateLimiter) middleware(next RoundTrip) RoundTrip {
+	return func(req *http.Request) (*Response, error) {
+		if err := rl.wait(req.Context()); err != nil {
+			return nil, err
+		}
+
+		return next(req)
+	}
+}
+
+// wait takes a token, sleeping until one is available.
+func (rl *rateLimiter) wait(ctx context.Context) error {
+	rl.mu.Lock()
+
+	now := rl.now()
+	rl.tokens += now.Sub(rl.last).Seconds() * rl.rate
+	if rl.tokens > rl.burst {
+		rl.tokens = rl.burst
+	}
+	rl.last = now
+
+	// the token is reserved up front so that waiting requests keep their order
+	rl.tokens--
+	delay := time.Duration(-rl.tokens / rl.rate * float64(time.Second))
+
+	rl.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		rl.mu.Lock()
+		rl.tokens++
+		rl.mu.Unlock()
+
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
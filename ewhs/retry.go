@@ -0,0 +1,130 @@
+package ewhs
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultRetryWait = 500 * time.Millisecond
+	maxRetryWait     = time.Minute
+)
+
+// withAttempt stores the attempt number of a request in its context.
+func withAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, attemptKey{}, attempt)
+}
+
+// retryMiddleware retries idempotent requests that failed with a transport
+// error or a temporary server status, up to Config.MaxRetries times. POST
+// requests are retried only when they carry an idempotency key the API honours.
+func (c *Client) retryMiddleware(next RoundTrip) RoundTrip {
+	return func(req *http.Request) (*Response, error) {
+		retries := 0
+		if c.config != nil {
+			retries = c.config.MaxRetries
+		}
+
+		wait := c.retryWait()
+
+		ctx := req.Context()
+
+		for attempt := 1; ; attempt++ {
+			r := req.WithContext(withAttempt(ctx, attempt))
+
+			if attempt > 1 && req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				r.Body = body
+			}
+
+			res, err := next(r)
+			if attempt > retries || !c.shouldRetry(r, res, err) {
+				return res, err
+			}
+
+			if !sleep(ctx, retryDelay(res, wait, attempt)) {
+				return res, err
+			}
+		}
+	}
+}
+
+// retryWait returns the initial backoff between retries.
+func (c *Client) retryWait() time.Duration {
+	if c.config != nil && c.config.RetryWait > 0 {
+		return c.config.RetryWait
+	}
+
+	return defaultRetryWait
+}
+
+// sleep waits for d and reports false when ctx is done first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+func (c *Client) shouldRetry(req *http.Request, res *Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	keyed := req.Method == http.MethodPost && req.Header.Get(IdempotencyKeyHeader) != "" &&
+		c.config != nil && c.config.IdempotencyKeys
+
+	if !isIdempotent(req) && !keyed {
+		return false
+	}
+
+	if res == nil {
+		return err != nil
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// retryDelay honours a Retry-After header in seconds and otherwise backs off
+// exponentially, up to maxRetryWait.
+func retryDelay(res *Response, wait time.Duration, attempt int) time.Duration {
+	if res != nil {
+		if s, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil && s >= 0 {
+			return time.Duration(s) * time.Second
+		}
+	}
+
+	for i := 1; i < attempt && wait < maxRetryWait; i++ {
+		wait *= 2
+	}
+
+	if wait > maxRetryWait {
+		wait = maxRetryWait
+	}
+
+	return wait
+}
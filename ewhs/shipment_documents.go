@@ -0,0 +1,141 @@
+package ewhs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+
+	"github.com/google/go-querystring/query"
+)
+
+type ShipmentDocumentFormat string
+
+const (
+	ShipmentDocumentPDF ShipmentDocumentFormat = "pdf"
+	ShipmentDocumentZPL ShipmentDocumentFormat = "zpl"
+)
+
+const (
+	ContentTypePDF   = "application/pdf"
+	ContentTypeZPL   = "application/x-zpl"
+	ContentTypeOctet = "application/octet-stream"
+)
+
+// sniffLen is the number of leading bytes used to detect the content type.
+const sniffLen int = 512
+
+// ShipmentDocument describes a downloaded label or packing slip.
+type ShipmentDocument struct {
+	ShipmentID  string
+	LabelCode   string
+	ContentType string
+	Size        int64
+}
+
+type shipmentDocumentOptions struct {
+	Format ShipmentDocumentFormat `url:"format,omitempty"`
+}
+
+// DownloadLabel streams a shipping label of a shipment to w. An empty format
+// leaves the choice to the API, which uses the format of the shipping method.
+func (ss *ShipmentsService) DownloadLabel(ctx context.Context, shipmentID string, labelCode string, format ShipmentDocumentFormat, w io.Writer) (doc *ShipmentDocument, res *Response, err error) {
+	uri := fmt.Sprintf("wms/shipments/%s/labels/%s/", shipmentID, url.PathEscape(labelCode))
+
+	doc, res, err = ss.download(ctx, uri, format, w)
+	if doc != nil {
+		doc.ShipmentID = shipmentID
+		doc.LabelCode = labelCode
+	}
+
+	return
+}
+
+// DownloadLabels streams all shipping labels of a shipment to w as one document.
+func (ss *ShipmentsService) DownloadLabels(ctx context.Context, shipmentID string, format ShipmentDocumentFormat, w io.Writer) (doc *ShipmentDocument, res *Response, err error) {
+	doc, res, err = ss.download(ctx, fmt.Sprintf("wms/shipments/%s/labels/", shipmentID), format, w)
+	if doc != nil {
+		doc.ShipmentID = shipmentID
+	}
+
+	return
+}
+
+// DownloadPackingSlip streams the packing slip of a shipment to w.
+func (ss *ShipmentsService) DownloadPackingSlip(ctx context.Context, shipmentID string, w io.Writer) (doc *ShipmentDocument, res *Response, err error) {
+	doc, res, err = ss.download(ctx, fmt.Sprintf("wms/shipments/%s/packing-slip/", shipmentID), ShipmentDocumentPDF, w)
+	if doc != nil {
+		doc.ShipmentID = shipmentID
+	}
+
+	return
+}
+
+func (ss *ShipmentsService) download(ctx context.Context, uri string, format ShipmentDocumentFormat, w io.Writer) (*ShipmentDocument, *Response, error) {
+	if format != "" {
+		v, _ := query.Values(shipmentDocumentOptions{Format: format})
+		uri = fmt.Sprintf("%s?%s", uri, v.Encode())
+	}
+
+	sw := &sniffWriter{w: w}
+
+	res, err := ss.client.stream(ctx, uri, sw)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return &ShipmentDocument{
+		ContentType: DetectDocumentType(res.Header.Get("Content-Type"), sw.head.Bytes()),
+		Size:        sw.n,
+	}, res, nil
+}
+
+// DetectDocumentType returns the media type of a shipment document. A
+// specific Content-Type header wins; otherwise the leading bytes are
+// inspected for PDF and ZPL signatures.
+func DetectDocumentType(header string, head []byte) string {
+	if mt, _, err := mime.ParseMediaType(header); err == nil && mt != ContentTypeOctet && mt != "text/plain" {
+		return mt
+	}
+
+	trimmed := bytes.TrimLeft(head, " \t\r\n")
+
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("%PDF-")):
+		return ContentTypePDF
+	case bytes.HasPrefix(trimmed, []byte("^XA")), bytes.HasPrefix(trimmed, []byte("~DG")):
+		return ContentTypeZPL
+	}
+
+	if mt, _, err := mime.ParseMediaType(http.DetectContentType(head)); err == nil {
+		return mt
+	}
+
+	return ContentTypeOctet
+}
+
+// sniffWriter passes writes through to w while keeping the leading bytes for
+// content type detection.
+type sniffWriter struct {
+	w    io.Writer
+	head bytes.Buffer
+	n    int64
+}
+
+func (sw *sniffWriter) Write(p []byte) (int, error) {
+	if rest := sniffLen - sw.head.Len(); rest > 0 {
+		if rest > len(p) {
+			rest = len(p)
+		}
+
+		sw.head.Write(p[:rest])
+	}
+
+	n, err := sw.w.Write(p)
+	sw.n += int64(n)
+
+	return n, err
+}
@@ -0,0 +1,71 @@
+package ewhs
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShipmentsService_DownloadLabel(t *testing.T) {
+	tests := []struct {
+		name        string
+		format      ShipmentDocumentFormat
+		contentType string
+		body        string
+		want        string
+	}{
+		{"pdf from the header", ShipmentDocumentPDF, "application/pdf", "%PDF-1.4 label", ContentTypePDF},
+		{"pdf sniffed from the content", ShipmentDocumentPDF, "application/octet-stream", "%PDF-1.4 label", ContentTypePDF},
+		{"zpl sniffed from the content", ShipmentDocumentZPL, "text/plain; charset=utf-8", "\n^XA^FO50,50^FDlabel^FS^XZ", ContentTypeZPL},
+		{"format chosen by the API", "", "", "^XA^XZ", ContentTypeZPL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup()
+			defer teardown()
+
+			tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+
+			tMux.HandleFunc("/wms/shipments/5a4e1c1c/labels/3SABCD0000001/", func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, "GET")
+				assert.Equal(t, string(tt.format), r.URL.Query().Get("format"))
+
+				if tt.contentType != "" {
+					w.Header().Set("Content-Type", tt.contentType)
+				} else {
+					w.Header()["Content-Type"] = nil
+				}
+
+				_, _ = w.Write([]byte(tt.body))
+			})
+
+			var buf bytes.Buffer
+
+			doc, _, err := tClient.Shipments.DownloadLabel(context.Background(), "5a4e1c1c", "3SABCD0000001", tt.format, &buf)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.body, buf.String())
+			assert.Equal(t, tt.want, doc.ContentType)
+			assert.Equal(t, int64(len(tt.body)), doc.Size)
+			assert.Equal(t, "3SABCD0000001", doc.LabelCode)
+		})
+	}
+}
+
+func TestShipmentsService_DownloadPackingSlipError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+	tMux.HandleFunc("/wms/shipments/5a4e1c1c/packing-slip/", errorHandler)
+
+	var buf bytes.Buffer
+
+	doc, _, err := tClient.Shipments.DownloadPackingSlip(context.Background(), "5a4e1c1c", &buf)
+	assert.EqualError(t, err, "500 - 500 Internal Server Error")
+	assert.Nil(t, doc)
+	assert.Equal(t, 0, buf.Len())
+}
@@ -3,10 +3,14 @@ package ewhs
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
 	"time"
 )
 
+var errNoExternalReference = errors.New("order has no external reference")
+
 type ShipmentsService service
 
 type Shipment struct {
@@ -15,7 +19,7 @@ type Shipment struct {
 	CreatedAt                 time.Time              `json:"created_at,omitempty"`
 	OrderID                   string                 `json:"order_id,omitempty"`
 	OrderExternalReference    string                 `json:"order_external_reference,omitempty"`
-	ShipmentExternalReference interface{}            `json:"shipment_external_reference,omitempty"`
+	ShipmentExternalReference Nullable[string]       `json:"shipment_external_reference,omitempty"`
 	Reference                 string                 `json:"reference,omitempty"`
 	ShippingMethod            ShipmentShippingMethod `json:"shipping_method,omitempty"`
 	ShipmentLabels            []ShipmentLabels       `json:"shipment_labels,omitempty"`
@@ -37,12 +41,80 @@ type ShipmentLabels struct {
 }
 
 type ShipmentLines struct {
-	ShippedQuantity    int           `json:"shipped_quantity,omitempty"`
-	ShippedEan         string        `json:"shipped_ean,omitempty"`
-	ShippedArticleCode string        `json:"shipped_article_code,omitempty"`
-	ShippedSku         string        `json:"shipped_sku,omitempty"`
-	SerialNumbers      []interface{} `json:"serial_numbers,omitempty"`
-	Variant            Variant       `json:"variant,omitempty"`
+	ShippedQuantity    int      `json:"shipped_quantity,omitempty"`
+	ShippedEan         string   `json:"shipped_ean,omitempty"`
+	ShippedArticleCode string   `json:"shipped_article_code,omitempty"`
+	ShippedSku         string   `json:"shipped_sku,omitempty"`
+	SerialNumbers      []string `json:"serial_numbers,omitempty"`
+	Variant            Variant  `json:"variant,omitempty"`
+}
+
+type OrderShipmentStatus string
+
+const (
+	OrderNotShipped       OrderShipmentStatus = "not_shipped"
+	OrderPartiallyShipped OrderShipmentStatus = "partially_shipped"
+	OrderShipped          OrderShipmentStatus = "shipped"
+)
+
+// OrderShipments holds all shipments of an order with their labels and the
+// shipped quantity of every order line.
+type OrderShipments struct {
+	Order     *Order
+	Shipments []Shipment
+	Labels    []ShipmentLabels
+	Lines     []OrderLineShipment
+}
+
+// OrderLineShipment compares the ordered with the shipped quantity of an
+// article. Articles that were shipped but not ordered have no ordered quantity.
+type OrderLineShipment struct {
+	ArticleCode string
+	Ordered     int
+	Shipped     int
+}
+
+// Remaining returns the quantity that has not been shipped yet.
+func (l OrderLineShipment) Remaining() int {
+	if l.Shipped >= l.Ordered {
+		return 0
+	}
+
+	return l.Ordered - l.Shipped
+}
+
+// Status reports whether nothing, part or all of the order has been shipped.
+func (os *OrderShipments) Status() OrderShipmentStatus {
+	shipped, remaining := 0, 0
+
+	for _, l := range os.Lines {
+		shipped += l.Shipped
+		remaining += l.Remaining()
+	}
+
+	switch {
+	case shipped == 0:
+		return OrderNotShipped
+	case remaining > 0:
+		return OrderPartiallyShipped
+	}
+
+	return OrderShipped
+}
+
+// TrackingURLs returns the distinct tracking URLs of all shipments.
+func (os *OrderShipments) TrackingURLs() []string {
+	var urls []string
+	seen := map[string]bool{}
+
+	for _, l := range os.Labels {
+		if l.TrackingURL != "" && !seen[l.TrackingURL] {
+			seen[l.TrackingURL] = true
+			urls = append(urls, l.TrackingURL)
+		}
+	}
+
+	return urls
 }
 
 type ShipmentListOptions struct {
@@ -83,3 +155,90 @@ func (ss *ShipmentsService) Get(ctx context.Context, shipmentID string) (shipmen
 
 	return
 }
+
+// ForOrder returns all shipments of an order, which is looked up by its
+// external reference and otherwise by its ID, and compares the shipped with
+// the ordered quantities. Shipments are listed by the external reference of
+// the order, so an order without one returns an error.
+func (ss *ShipmentsService) ForOrder(ctx context.Context, orderRefOrID string) (*OrderShipments, error) {
+	order, _, err := ss.client.Orders.FindByExternalReference(ctx, orderRefOrID)
+	if err != nil {
+		return nil, err
+	}
+
+	orderID := orderRefOrID
+	if order != nil {
+		orderID = order.ID
+	}
+
+	// order lines are only returned when they are expanded
+	expand := "order_lines"
+	if e, ok := ctx.Value("Expand").(string); ok && e != "" {
+		expand = e + "," + expand
+	}
+
+	if order, _, err = ss.client.Orders.Get(context.WithValue(ctx, "Expand", expand), orderID); err != nil {
+		return nil, err
+	}
+
+	if order.ExternalReference == "" {
+		return nil, fmt.Errorf("order %s: %w", order.ID, errNoExternalReference)
+	}
+
+	opts := ShipmentListOptions{OrderExternalReference: []string{order.ExternalReference}}
+
+	shipments, err := listPages(0, func(page int, limit int) (*[]Shipment, *Response, error) {
+		opts.Page = page
+		opts.Limit = limit
+
+		return ss.List(ctx, &opts)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(shipments, func(i, j int) bool { return shipments[i].CreatedAt.Before(shipments[j].CreatedAt) })
+
+	os := &OrderShipments{Order: order}
+	index := map[string]int{}
+
+	line := func(code string) *OrderLineShipment {
+		i, ok := index[code]
+		if !ok {
+			i = len(os.Lines)
+			index[code] = i
+			os.Lines = append(os.Lines, OrderLineShipment{ArticleCode: code})
+		}
+
+		return &os.Lines[i]
+	}
+
+	for _, l := range order.OrderLines {
+		code := l.ArticleCode
+		if code == "" && l.Variant != nil {
+			code = l.Variant.ArticleCode
+		}
+
+		line(code).Ordered += l.Quantity
+	}
+
+	for _, s := range shipments {
+		if s.OrderID != "" && order.ID != "" && s.OrderID != order.ID {
+			continue
+		}
+
+		os.Shipments = append(os.Shipments, s)
+		os.Labels = append(os.Labels, s.ShipmentLabels...)
+
+		for _, l := range s.ShipmentLines {
+			code := l.ShippedArticleCode
+			if code == "" {
+				code = l.Variant.ArticleCode
+			}
+
+			line(code).Shipped += l.ShippedQuantity
+		}
+	}
+
+	return os, nil
+}
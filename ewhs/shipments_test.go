@@ -0,0 +1,123 @@
+package ewhs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/ewarehousing-solutions/ewhs-api-go/test/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShipmentsService_ForOrder(t *testing.T) {
+	tests := []struct {
+		name       string
+		ref        string
+		shipments  string
+		wantStatus OrderShipmentStatus
+		wantLines  []OrderLineShipment
+		wantLabels int
+	}{
+		{
+			"all shipments are stitched together",
+			"c9165f93-8301-4aaa-9f64-27f191c0c778",
+			testdata.ListShipmentsResponse,
+			OrderPartiallyShipped,
+			[]OrderLineShipment{
+				{ArticleCode: "default_variant_b_id", Ordered: 7, Shipped: 7},
+				{ArticleCode: "default_variant_a_id", Ordered: 15, Shipped: 13},
+			},
+			2,
+		},
+		{
+			"nothing has been shipped yet, order found by external reference",
+			"1644571933",
+			`[]`,
+			OrderNotShipped,
+			[]OrderLineShipment{
+				{ArticleCode: "default_variant_b_id", Ordered: 7},
+				{ArticleCode: "default_variant_a_id", Ordered: 15},
+			},
+			0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup()
+			defer teardown()
+
+			tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+
+			tMux.HandleFunc("/wms/orders/", func(w http.ResponseWriter, r *http.Request) {
+				testQuery(t, r, "external_reference="+tt.ref)
+
+				// the list leaves out the order lines
+				if tt.ref == "1644571933" {
+					_, _ = w.Write([]byte(`[{"id":"c9165f93-8301-4aaa-9f64-27f191c0c778","external_reference":"1644571933"}]`))
+					return
+				}
+
+				_, _ = w.Write([]byte(`[]`))
+			})
+			tMux.HandleFunc("/wms/orders/c9165f93-8301-4aaa-9f64-27f191c0c778/", func(w http.ResponseWriter, r *http.Request) {
+				testHeader(t, r, "Expand", "order_lines")
+				_, _ = w.Write([]byte(testdata.GetOrderResponse))
+			})
+			tMux.HandleFunc("/wms/shipments/", func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "1644571933", r.URL.Query().Get("order_external_reference[]"))
+				_, _ = w.Write([]byte(tt.shipments))
+			})
+
+			os, err := tClient.Shipments.ForOrder(context.Background(), tt.ref)
+			assert.Nil(t, err)
+			assert.Equal(t, "1644571933", os.Order.ExternalReference)
+			assert.Equal(t, tt.wantLines, os.Lines)
+			assert.Len(t, os.Labels, tt.wantLabels)
+
+			if tt.wantLabels > 0 {
+				assert.Equal(t, "SHP00000000001", os.Shipments[0].Reference, "shipments are sorted by creation")
+				assert.Equal(t, []string{
+					"https://tracking.example.com/3SABCD0000001",
+					"https://tracking.example.com/3SABCD0000002",
+				}, os.TrackingURLs())
+			}
+
+			assert.Equal(t, tt.wantStatus, os.Status())
+		})
+	}
+}
+
+func TestShipmentsService_ForOrderWithoutExternalReference(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+
+	tMux.HandleFunc("/wms/orders/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	})
+	tMux.HandleFunc("/wms/orders/o1/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"o1"}`))
+	})
+	tMux.HandleFunc("/wms/shipments/", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no shipments should be listed without an external reference")
+	})
+
+	_, err := tClient.Shipments.ForOrder(context.Background(), "o1")
+	assert.True(t, errors.Is(err, errNoExternalReference))
+}
+
+func TestOrderShipments_Status(t *testing.T) {
+	os := &OrderShipments{Lines: []OrderLineShipment{
+		{ArticleCode: "a", Ordered: 7, Shipped: 7},
+		{ArticleCode: "b", Ordered: 15, Shipped: 13},
+	}}
+
+	assert.Equal(t, OrderPartiallyShipped, os.Status())
+	assert.Equal(t, 2, os.Lines[1].Remaining())
+
+	os.Lines[1].Shipped = 15
+	assert.Equal(t, OrderShipped, os.Status())
+}
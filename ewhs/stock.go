@@ -9,17 +9,17 @@ import (
 type StockService service
 
 type Stock struct {
-	ID              string      `json:"id,omitempty"`
-	ArticleCode     string      `json:"article_code,omitempty"`
-	Ean             string      `json:"ean,omitempty"`
-	Sku             interface{} `json:"sku,omitempty"`
-	StockPhysical   int         `json:"stock_physical,omitempty"`
-	StockSalable    int         `json:"stock_salable,omitempty"`
-	StockAvailable  int         `json:"stock_available,omitempty"`
-	StockQuarantine int         `json:"stock_quarantine,omitempty"`
-	StockPickable   int         `json:"stock_pickable,omitempty"`
-	ModifiedAt      time.Time   `json:"modified_at,omitempty"`
-	Variant         Variant     `json:"variant,omitempty"`
+	ID              string           `json:"id,omitempty"`
+	ArticleCode     string           `json:"article_code,omitempty"`
+	Ean             string           `json:"ean,omitempty"`
+	Sku             Nullable[string] `json:"sku,omitempty"`
+	StockPhysical   int              `json:"stock_physical,omitempty"`
+	StockSalable    int              `json:"stock_salable,omitempty"`
+	StockAvailable  int              `json:"stock_available,omitempty"`
+	StockQuarantine int              `json:"stock_quarantine,omitempty"`
+	StockPickable   int              `json:"stock_pickable,omitempty"`
+	ModifiedAt      time.Time        `json:"modified_at,omitempty"`
+	Variant         Variant          `json:"variant,omitempty"`
 }
 
 type StockListOptions struct {
@@ -32,6 +32,7 @@ type StockListOptions struct {
 	From        string `url:"from,omitempty"`
 	To          string `url:"to,omitempty"`
 	Limit       int    `url:"limit,omitempty"`
+	Sort        string `url:"sort,omitempty"`
 	Direction   string `url:"direction,omitempty"`
 }
 
@@ -0,0 +1,215 @@
+package ewhs
+
+import "sort"
+
+type StockField string
+
+const (
+	StockFieldPhysical   StockField = "stock_physical"
+	StockFieldSalable    StockField = "stock_salable"
+	StockFieldAvailable  StockField = "stock_available"
+	StockFieldQuarantine StockField = "stock_quarantine"
+	StockFieldPickable   StockField = "stock_pickable"
+)
+
+// StockFields are the quantity fields compared by DiffStock.
+var StockFields = []StockField{
+	StockFieldPhysical,
+	StockFieldSalable,
+	StockFieldAvailable,
+	StockFieldQuarantine,
+	StockFieldPickable,
+}
+
+// Quantity returns the value of a quantity field.
+func (s Stock) Quantity(field StockField) int {
+	switch field {
+	case StockFieldPhysical:
+		return s.StockPhysical
+	case StockFieldSalable:
+		return s.StockSalable
+	case StockFieldAvailable:
+		return s.StockAvailable
+	case StockFieldQuarantine:
+		return s.StockQuarantine
+	case StockFieldPickable:
+		return s.StockPickable
+	}
+
+	return 0
+}
+
+// StockFieldDiff is a changed quantity field.
+type StockFieldDiff struct {
+	Field StockField
+	Old   int
+	New   int
+}
+
+// Delta returns the new minus the old quantity.
+func (d StockFieldDiff) Delta() int {
+	return d.New - d.Old
+}
+
+// StockDiff holds the changed fields of one article. Old is nil when the
+// article was added and New is nil when it was removed.
+type StockDiff struct {
+	Key    string
+	Old    *Stock
+	New    *Stock
+	Fields []StockFieldDiff
+}
+
+type StockEventType string
+
+const (
+	StockEventOutOfStock  StockEventType = "out_of_stock"
+	StockEventBackInStock StockEventType = "back_in_stock"
+	StockEventLowStock    StockEventType = "low_stock"
+)
+
+// StockEvent reports that the watched quantity of an article crossed zero or
+// its low-stock threshold.
+type StockEvent struct {
+	Type      StockEventType
+	Key       string
+	Field     StockField
+	Old       int
+	New       int
+	Threshold int
+}
+
+// StockDiffOptions configures DiffStock. The zero value keys records by
+// article code and watches StockAvailable without low-stock alerts.
+type StockDiffOptions struct {
+	// Key returns the key records are matched by, e.g. StockKeyEan.
+	Key func(Stock) string
+	// Field is the quantity events are raised for.
+	Field StockField
+	// LowStockThreshold raises a low-stock event when the quantity drops to
+	// or below it. Zero disables low-stock events.
+	LowStockThreshold int
+	// Thresholds overrides LowStockThreshold per key.
+	Thresholds map[string]int
+}
+
+// StockKeyArticleCode keys stock records by article code.
+func StockKeyArticleCode(s Stock) string {
+	return s.ArticleCode
+}
+
+// StockKeyEan keys stock records by EAN.
+func StockKeyEan(s Stock) string {
+	return s.Ean
+}
+
+// StockDiffResult holds the changes between two stock snapshots, sorted by key.
+type StockDiffResult struct {
+	Diffs  []StockDiff
+	Events []StockEvent
+}
+
+// DiffStock compares two stock snapshots, for example two results of
+// StockService.List. An article missing from a snapshot counts as having no
+// stock for events, so an article that disappears goes out of stock.
+func DiffStock(previous []Stock, current []Stock, opts *StockDiffOptions) *StockDiffResult {
+	var o StockDiffOptions
+	if opts != nil {
+		o = *opts
+	}
+
+	if o.Key == nil {
+		o.Key = StockKeyArticleCode
+	}
+
+	if o.Field == "" {
+		o.Field = StockFieldAvailable
+	}
+
+	before := indexStock(previous, o.Key)
+	after := indexStock(current, o.Key)
+
+	keys := make([]string, 0, len(before)+len(after))
+	for k := range before {
+		keys = append(keys, k)
+	}
+
+	for k := range after {
+		if _, ok := before[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+
+	result := &StockDiffResult{}
+
+	for _, k := range keys {
+		b, a := before[k], after[k]
+
+		var bs, as Stock
+		if b != nil {
+			bs = *b
+		}
+
+		if a != nil {
+			as = *a
+		}
+
+		d := StockDiff{Key: k, Old: b, New: a}
+		for _, f := range StockFields {
+			if bq, aq := bs.Quantity(f), as.Quantity(f); bq != aq || b == nil || a == nil {
+				d.Fields = append(d.Fields, StockFieldDiff{Field: f, Old: bq, New: aq})
+			}
+		}
+
+		if len(d.Fields) > 0 {
+			result.Diffs = append(result.Diffs, d)
+		}
+
+		threshold := o.LowStockThreshold
+		if t, ok := o.Thresholds[k]; ok {
+			threshold = t
+		}
+
+		result.Events = append(result.Events, stockEvents(k, o.Field, bs.Quantity(o.Field), as.Quantity(o.Field), threshold)...)
+	}
+
+	return result
+}
+
+func stockEvents(key string, field StockField, from int, to int, threshold int) []StockEvent {
+	e := StockEvent{Key: key, Field: field, Old: from, New: to}
+
+	switch {
+	case from > 0 && to <= 0:
+		e.Type = StockEventOutOfStock
+	case from <= 0 && to > 0:
+		e.Type = StockEventBackInStock
+	}
+
+	var events []StockEvent
+	if e.Type != "" {
+		events = append(events, e)
+	}
+
+	if threshold > 0 && to > 0 && to <= threshold && from > threshold {
+		e.Type = StockEventLowStock
+		e.Threshold = threshold
+		events = append(events, e)
+	}
+
+	return events
+}
+
+func indexStock(list []Stock, key func(Stock) string) map[string]*Stock {
+	index := make(map[string]*Stock, len(list))
+
+	for i := range list {
+		if k := key(list[i]); k != "" {
+			index[k] = &list[i]
+		}
+	}
+
+	return index
+}
@@ -0,0 +1,95 @@
+package ewhs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffStock(t *testing.T) {
+	old := []Stock{
+		{ArticleCode: "cream", Ean: "8712345000011", StockPhysical: 10, StockAvailable: 10, StockSalable: 10},
+		{ArticleCode: "soap", Ean: "8712345000028", StockPhysical: 3, StockAvailable: 3},
+		{ArticleCode: "brush", Ean: "8712345000035", StockAvailable: 0},
+		{ArticleCode: "towel", Ean: "8712345000042", StockAvailable: 20},
+		{ArticleCode: "sponge", Ean: "8712345000059", StockAvailable: 2},
+	}
+
+	current := []Stock{
+		{ArticleCode: "cream", Ean: "8712345000011", StockPhysical: 10, StockAvailable: 4, StockSalable: 4},
+		{ArticleCode: "soap", Ean: "8712345000028", StockPhysical: 3, StockAvailable: 0, StockQuarantine: 3},
+		{ArticleCode: "brush", Ean: "8712345000035", StockAvailable: 6},
+		{ArticleCode: "towel", Ean: "8712345000042", StockAvailable: 20},
+		{ArticleCode: "comb", Ean: "8712345000066", StockAvailable: 1},
+	}
+
+	tests := []struct {
+		name       string
+		opts       *StockDiffOptions
+		wantKeys   []string
+		wantEvents []StockEvent
+	}{
+		{
+			"defaults",
+			nil,
+			[]string{"brush", "comb", "cream", "soap", "sponge"},
+			[]StockEvent{
+				{Type: StockEventBackInStock, Key: "brush", Field: StockFieldAvailable, Old: 0, New: 6},
+				{Type: StockEventBackInStock, Key: "comb", Field: StockFieldAvailable, Old: 0, New: 1},
+				{Type: StockEventOutOfStock, Key: "soap", Field: StockFieldAvailable, Old: 3, New: 0},
+				{Type: StockEventOutOfStock, Key: "sponge", Field: StockFieldAvailable, Old: 2, New: 0},
+			},
+		},
+		{
+			"low-stock thresholds",
+			&StockDiffOptions{LowStockThreshold: 5, Thresholds: map[string]int{"brush": 8}},
+			[]string{"brush", "comb", "cream", "soap", "sponge"},
+			[]StockEvent{
+				{Type: StockEventBackInStock, Key: "brush", Field: StockFieldAvailable, Old: 0, New: 6},
+				{Type: StockEventBackInStock, Key: "comb", Field: StockFieldAvailable, Old: 0, New: 1},
+				{Type: StockEventLowStock, Key: "cream", Field: StockFieldAvailable, Old: 10, New: 4, Threshold: 5},
+				{Type: StockEventOutOfStock, Key: "soap", Field: StockFieldAvailable, Old: 3, New: 0},
+				{Type: StockEventOutOfStock, Key: "sponge", Field: StockFieldAvailable, Old: 2, New: 0},
+			},
+		},
+		{
+			"keyed by ean watching salable stock",
+			&StockDiffOptions{Key: StockKeyEan, Field: StockFieldSalable},
+			[]string{"8712345000011", "8712345000028", "8712345000035", "8712345000059", "8712345000066"},
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := DiffStock(old, current, tt.opts)
+
+			var keys []string
+			for _, d := range result.Diffs {
+				keys = append(keys, d.Key)
+			}
+
+			assert.Equal(t, tt.wantKeys, keys)
+			assert.Equal(t, tt.wantEvents, result.Events)
+		})
+	}
+}
+
+func TestDiffStock_Fields(t *testing.T) {
+	result := DiffStock(
+		[]Stock{{ArticleCode: "soap", StockPhysical: 3, StockAvailable: 3}},
+		[]Stock{{ArticleCode: "soap", StockPhysical: 3, StockAvailable: 0, StockQuarantine: 3}},
+		nil,
+	)
+
+	assert.Len(t, result.Diffs, 1)
+	assert.Equal(t, []StockFieldDiff{
+		{Field: StockFieldAvailable, Old: 3, New: 0},
+		{Field: StockFieldQuarantine, Old: 0, New: 3},
+	}, result.Diffs[0].Fields)
+	assert.Equal(t, -3, result.Diffs[0].Fields[0].Delta())
+
+	removed := DiffStock([]Stock{{ArticleCode: "soap", StockPhysical: 3}}, nil, nil)
+	assert.Nil(t, removed.Diffs[0].New)
+	assert.Len(t, removed.Diffs[0].Fields, len(StockFields), "all fields of a removed article are reported")
+}
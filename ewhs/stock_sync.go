@@ -0,0 +1,272 @@
+package ewhs
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultStockSyncOverlap = time.Minute
+
+// StockChanges iterates over the stock records modified since a point in
+// time, oldest first. Every query starts at the ModifiedAt of the last record
+// seen, so records modified while iterating do not shift the pages; a record
+// is returned again only when it was modified again.
+//
+//	changes := client.Stock.Changes(ctx, since)
+//	for changes.Next() {
+//		stock := changes.Stock()
+//	}
+//	if err := changes.Err(); err != nil {
+//		...
+//	}
+type StockChanges struct {
+	ctx  context.Context
+	ss   *StockService
+	opts StockListOptions
+	page []Stock
+	seen map[string]time.Time
+	cur  Stock
+	done bool
+	err  error
+}
+
+// Changes returns an iterator over the stock records modified at or after since.
+func (ss *StockService) Changes(ctx context.Context, since time.Time) *StockChanges {
+	opts := StockListOptions{Page: 1, Limit: defaultPageSize, Sort: "modified_at", Direction: "asc"}
+	if !since.IsZero() {
+		opts.ModifiedGte = formatModifiedGte(since)
+	}
+
+	return &StockChanges{
+		ctx:  ctx,
+		ss:   ss,
+		opts: opts,
+		seen: map[string]time.Time{},
+	}
+}
+
+// Next advances to the next record and reports whether there is one.
+func (sc *StockChanges) Next() bool {
+	for {
+		for len(sc.page) > 0 {
+			sc.cur = sc.page[0]
+			sc.page = sc.page[1:]
+
+			if last, ok := sc.seen[sc.cur.ID]; ok && !sc.cur.ModifiedAt.After(last) {
+				continue
+			}
+
+			sc.seen[sc.cur.ID] = sc.cur.ModifiedAt
+
+			return true
+		}
+
+		if sc.done || sc.err != nil {
+			return false
+		}
+
+		list, _, err := sc.ss.List(sc.ctx, &sc.opts)
+		if err != nil {
+			sc.err = err
+			return false
+		}
+
+		if list == nil || len(*list) < sc.opts.Limit {
+			sc.done = true
+			if list != nil {
+				sc.page = *list
+			}
+
+			continue
+		}
+
+		sc.page = *list
+
+		// a full page modified within the same second does not move the
+		// cursor, so the next page of that second is fetched instead
+		cursor := formatModifiedGte(sc.page[len(sc.page)-1].ModifiedAt)
+		if cursor == sc.opts.ModifiedGte {
+			sc.opts.Page++
+		} else {
+			sc.opts.ModifiedGte = cursor
+			sc.opts.Page = 1
+		}
+	}
+}
+
+// Stock returns the current record.
+func (sc *StockChanges) Stock() Stock {
+	return sc.cur
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (sc *StockChanges) Err() error {
+	return sc.err
+}
+
+func formatModifiedGte(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func stockChangeKey(s Stock) string {
+	return s.ID + "@" + s.ModifiedAt.UTC().Format(time.RFC3339Nano)
+}
+
+// CheckpointStore persists the high-water mark of a StockSyncer. Load returns
+// the zero time when no checkpoint was saved yet.
+type CheckpointStore interface {
+	Load(ctx context.Context) (time.Time, error)
+	Save(ctx context.Context, t time.Time) error
+}
+
+// MemoryCheckpointStore keeps the checkpoint in memory.
+type MemoryCheckpointStore struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func (m *MemoryCheckpointStore) Load(_ context.Context) (time.Time, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.t, nil
+}
+
+func (m *MemoryCheckpointStore) Save(_ context.Context, t time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.t = t
+
+	return nil
+}
+
+// FileCheckpointStore keeps the checkpoint as an RFC 3339 timestamp in a file.
+type FileCheckpointStore string
+
+func (f FileCheckpointStore) Load(_ context.Context) (time.Time, error) {
+	b, err := os.ReadFile(string(f))
+	if errors.Is(err, os.ErrNotExist) {
+		return time.Time{}, nil
+	}
+
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Parse(time.RFC3339Nano, strings.TrimSpace(string(b)))
+}
+
+func (f FileCheckpointStore) Save(_ context.Context, t time.Time) error {
+	tmp := string(f) + ".tmp"
+	if err := os.WriteFile(tmp, []byte(t.UTC().Format(time.RFC3339Nano)+"\n"), 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, string(f))
+}
+
+// StockSyncer emits the stock records changed since the previous sync. The
+// high-water mark is the latest ModifiedAt seen, as reported by the API, so
+// the clock of the caller does not matter. Every sync starts Overlap before
+// the mark to pick up records that were committed late; records already
+// emitted in that window are skipped.
+//
+// Delivery is at least once: when fn fails the checkpoint is not advanced,
+// and after a restart records in the overlap window are emitted again.
+type StockSyncer struct {
+	Stock   *StockService
+	Store   CheckpointStore
+	Overlap time.Duration
+
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// NewStockSyncer returns a StockSyncer that keeps its checkpoint in store.
+func NewStockSyncer(ss *StockService, store CheckpointStore) *StockSyncer {
+	if store == nil {
+		store = &MemoryCheckpointStore{}
+	}
+
+	return &StockSyncer{
+		Stock:   ss,
+		Store:   store,
+		Overlap: defaultStockSyncOverlap,
+		seen:    map[string]time.Time{},
+	}
+}
+
+// Sync calls fn for every record changed since the previous sync and returns
+// the number of records emitted. The checkpoint is saved once all records
+// were handled.
+func (s *StockSyncer) Sync(ctx context.Context, fn func(Stock) error) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.seen == nil {
+		s.seen = map[string]time.Time{}
+	}
+
+	mark, err := s.Store.Load(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	since := mark
+	if !since.IsZero() {
+		since = since.Add(-s.Overlap)
+	}
+
+	n := 0
+	next := mark
+	emitted := map[string]time.Time{}
+
+	changes := s.Stock.Changes(ctx, since)
+	for changes.Next() {
+		stock := changes.Stock()
+
+		key := stockChangeKey(stock)
+		if _, ok := s.seen[key]; ok {
+			continue
+		}
+
+		if err := fn(stock); err != nil {
+			return n, err
+		}
+
+		n++
+		emitted[key] = stock.ModifiedAt
+
+		if stock.ModifiedAt.After(next) {
+			next = stock.ModifiedAt
+		}
+	}
+
+	if err := changes.Err(); err != nil {
+		return n, err
+	}
+
+	if next.After(mark) {
+		if err := s.Store.Save(ctx, next); err != nil {
+			return n, err
+		}
+	}
+
+	for key, t := range emitted {
+		s.seen[key] = t
+	}
+
+	// only records within the next overlap window can be returned again
+	for key, t := range s.seen {
+		if t.Before(next.Add(-s.Overlap)) {
+			delete(s.seen, key)
+		}
+	}
+
+	return n, nil
+}
@@ -0,0 +1,109 @@
+package ewhs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStockService_Changes(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+
+	// every query starts at the last record seen, s2 is returned again by the
+	// second query and s3 is modified again while iterating; s4 and s5 were
+	// modified in the same second, so their page is fetched by page number
+	pages := map[string]string{
+		"10:00:00 1": `[` + stockJSON("s1", "10:00:00") + `,` + stockJSON("s2", "10:01:00") + `]`,
+		"10:01:00 1": `[` + stockJSON("s2", "10:01:00") + `,` + stockJSON("s3", "10:02:00") + `]`,
+		"10:02:00 1": `[` + stockJSON("s4", "10:03:00") + `,` + stockJSON("s5", "10:03:00") + `]`,
+		"10:03:00 1": `[` + stockJSON("s4", "10:03:00") + `,` + stockJSON("s5", "10:03:00") + `]`,
+		"10:03:00 2": `[` + stockJSON("s3", "10:04:00") + `]`,
+	}
+
+	var queries []string
+
+	tMux.HandleFunc("/wms/stock/", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		assert.Equal(t, "modified_at", q.Get("sort"))
+		assert.Equal(t, "asc", q.Get("direction"))
+
+		key := strings.TrimSuffix(strings.TrimPrefix(q.Get("modified_gte"), "2022-02-01T"), "Z") + " " + q.Get("page")
+		queries = append(queries, key)
+		_, _ = w.Write([]byte(pages[key]))
+	})
+
+	changes := tClient.Stock.Changes(context.Background(), time.Date(2022, 2, 1, 11, 0, 0, 0, time.FixedZone("CET", 3600)))
+	changes.opts.Limit = 2
+
+	var ids []string
+	for changes.Next() {
+		ids = append(ids, changes.Stock().ID)
+	}
+
+	assert.Nil(t, changes.Err())
+	assert.Equal(t, []string{"s1", "s2", "s3", "s4", "s5", "s3"}, ids)
+	assert.Equal(t, []string{"10:00:00 1", "10:01:00 1", "10:02:00 1", "10:03:00 1", "10:03:00 2"}, queries)
+}
+
+func TestStockSyncer_Sync(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+
+	response := `[` + stockJSON("s1", "10:00:00") + `,` + stockJSON("s2", "10:05:00") + `]`
+	var queries []string
+
+	tMux.HandleFunc("/wms/stock/", func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("modified_gte"))
+		_, _ = w.Write([]byte(response))
+	})
+
+	store := FileCheckpointStore(filepath.Join(t.TempDir(), "stock.checkpoint"))
+	syncer := NewStockSyncer(tClient.Stock, store)
+
+	var ids []string
+	collect := func(s Stock) error {
+		ids = append(ids, s.ID)
+		return nil
+	}
+
+	n, err := syncer.Sync(context.Background(), collect)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, n)
+
+	mark, err := store.Load(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2022, 2, 1, 10, 5, 0, 0, time.UTC), mark)
+
+	// s2 is returned again because of the overlap, s3 was committed late
+	response = `[` + stockJSON("s2", "10:05:00") + `,` + stockJSON("s3", "10:04:30") + `,` + stockJSON("s1", "10:06:00") + `]`
+
+	n, err = syncer.Sync(context.Background(), collect)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []string{"s1", "s2", "s3", "s1"}, ids)
+	assert.Equal(t, []string{"", "2022-02-01T10:04:00Z"}, queries)
+
+	// a failing handler does not advance the checkpoint
+	response = `[` + stockJSON("s4", "10:10:00") + `]`
+
+	_, err = syncer.Sync(context.Background(), func(s Stock) error { return errors.New("webshop unavailable") })
+	assert.EqualError(t, err, "webshop unavailable")
+
+	mark, _ = store.Load(context.Background())
+	assert.Equal(t, time.Date(2022, 2, 1, 10, 6, 0, 0, time.UTC), mark)
+}
+
+func stockJSON(id string, modified string) string {
+	return `{"id": "` + id + `", "article_code": "` + id + `", "modified_at": "2022-02-01T` + modified + `+00:00"}`
+}
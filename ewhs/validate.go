@@ -0,0 +1,280 @@
+package ewhs
+
+import (
+	"fmt"
+	"net/http"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ValidationFailed is the title of the errors returned by Validate.
+const ValidationFailed string = "Validation Failed"
+
+// Field length limits checked by Validate.
+const (
+	maxReferenceLength = 64
+	maxTextLength      = 255
+	maxNoteLength      = 1024
+	maxZipcodeLength   = 16
+)
+
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+type violations []Violation
+
+func (v *violations) add(path string, format string, args ...interface{}) {
+	*v = append(*v, Violation{PropertyPath: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *violations) required(path string, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.add(path, "This value should not be blank.")
+	}
+}
+
+func (v *violations) maxLength(path string, value string, max int) {
+	if utf8.RuneCountInString(value) > max {
+		v.add(path, "This value is too long. It should have %d characters or less.", max)
+	}
+}
+
+func (v *violations) country(path string, value string) {
+	if value != "" && !IsCountryCode(value) {
+		v.add(path, "This value is not a valid country.")
+	}
+}
+
+func (v *violations) email(path string, value string) {
+	if value == "" {
+		return
+	}
+
+	if a, err := mail.ParseAddress(value); err != nil || a.Address != value {
+		v.add(path, "This value is not a valid email address.")
+	}
+}
+
+func (v *violations) date(path string, value string) {
+	if value == "" {
+		return
+	}
+
+	if _, err := time.Parse("2006-01-02", value); err == nil {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339, value); err != nil {
+		v.add(path, "This value is not a valid date.")
+	}
+}
+
+func (v *violations) positive(path string, value int) {
+	if value <= 0 {
+		v.add(path, "This value should be positive.")
+	}
+}
+
+func (v *violations) notNegative(path string, value int64) {
+	if value < 0 {
+		v.add(path, "This value should be either positive or zero.")
+	}
+}
+
+func (v *violations) amount(path string, value MajorUnits) {
+	r, ok := value.rat()
+
+	switch {
+	case !ok:
+		v.add(path, "This value is not a valid amount.")
+	case r.Sign() < 0:
+		v.add(path, "This value should be either positive or zero.")
+	}
+}
+
+// err returns the violations in the shape of a server validation error, or
+// nil when there are none.
+func (v violations) err() error {
+	if len(v) == 0 {
+		return nil
+	}
+
+	details := make([]string, len(v))
+	for i, vi := range v {
+		details[i] = vi.PropertyPath + ": " + vi.Message
+	}
+
+	return &BaseError{
+		Status:     http.StatusUnprocessableEntity,
+		Title:      ValidationFailed,
+		Detail:     strings.Join(details, "\n"),
+		Violations: v,
+	}
+}
+
+// Validate checks the order before it is created and returns all violations
+// at once as a *BaseError, like the API does.
+func (o Order) Validate() error {
+	var v violations
+
+	v.required("external_reference", o.ExternalReference)
+	v.maxLength("external_reference", o.ExternalReference, maxReferenceLength)
+	v.maxLength("external_id", o.ExternalID, maxReferenceLength)
+	v.maxLength("shipping_contactperson", o.ShippingContactperson, maxTextLength)
+	v.maxLength("customer_note", o.CustomerNote, maxNoteLength)
+	v.maxLength("note", o.Note, maxNoteLength)
+	v.email("shipping_email", o.ShippingEmail)
+	v.date("requested_delivery_date", o.RequestedDeliveryDate)
+	v.notNegative("order_amount", int64(o.OrderAmount))
+
+	if o.Currency != "" && !currencyPattern.MatchString(o.Currency) {
+		v.add("currency", "This value is not a valid currency.")
+	}
+
+	a := o.ShippingAddress
+	v.required("shipping_address.addressed_to", a.AddressedTo)
+	v.required("shipping_address.street", a.Street)
+	v.required("shipping_address.city", a.City)
+	v.required("shipping_address.zipcode", a.Zipcode)
+	v.required("shipping_address.country", a.Country)
+	v.country("shipping_address.country", a.Country)
+	v.maxLength("shipping_address.addressed_to", a.AddressedTo, maxTextLength)
+	v.maxLength("shipping_address.street", a.Street, maxTextLength)
+	v.maxLength("shipping_address.street2", a.Street2, maxTextLength)
+	v.maxLength("shipping_address.city", a.City, maxTextLength)
+	v.maxLength("shipping_address.zipcode", a.Zipcode, maxZipcodeLength)
+
+	if len(o.OrderLines) == 0 {
+		v.add("order_lines", "This collection should contain 1 element or more.")
+	}
+
+	seen := map[string]bool{}
+
+	for i, l := range o.OrderLines {
+		path := fmt.Sprintf("order_lines[%d]", i)
+
+		v.required(path+".article_code", l.ArticleCode)
+		v.maxLength(path+".article_code", l.ArticleCode, maxReferenceLength)
+		v.maxLength(path+".description", l.Description, maxTextLength)
+		v.positive(path+".quantity", l.Quantity)
+		v.amount(path+".price", l.Price)
+
+		if l.ArticleCode != "" && seen[l.ArticleCode] {
+			v.add(path+".article_code", "This article code is already used by another order line.")
+		}
+
+		seen[l.ArticleCode] = true
+	}
+
+	return v.err()
+}
+
+// Validate checks the inbound before it is created and returns all
+// violations at once as a *BaseError.
+func (i Inbound) Validate() error {
+	var v violations
+
+	v.required("external_reference", i.ExternalReference)
+	v.maxLength("external_reference", i.ExternalReference, maxReferenceLength)
+	v.maxLength("reference", i.Reference, maxReferenceLength)
+	v.maxLength("note", i.Note, maxNoteLength)
+	v.required("inbound_date", i.InboundDate)
+	v.date("inbound_date", i.InboundDate)
+
+	if len(i.InboundLines) == 0 {
+		v.add("inbound_lines", "This collection should contain 1 element or more.")
+	}
+
+	// an article may be announced once per lot
+	seen := map[string]bool{}
+
+	for n, l := range i.InboundLines {
+		path := fmt.Sprintf("inbound_lines[%d]", n)
+
+		v.required(path+".article_code", l.ArticleCode)
+		v.maxLength(path+".article_code", l.ArticleCode, maxReferenceLength)
+		v.maxLength(path+".lot_number", l.LotNumber, maxReferenceLength)
+		v.positive(path+".quantity", l.Quantity)
+		v.date(path+".expiry_date", l.ExpiryDate)
+
+		key := l.ArticleCode + "\x00" + l.LotNumber
+		if l.ArticleCode != "" && seen[key] {
+			v.add(path+".article_code", "This article code is already used by another inbound line.")
+		}
+
+		seen[key] = true
+	}
+
+	return v.err()
+}
+
+// Validate checks the article and its variants before it is created and
+// returns all violations at once as a *BaseError.
+func (a Article) Validate() error {
+	var v violations
+
+	v.required("name", a.Name)
+	v.maxLength("name", a.Name, maxTextLength)
+
+	if len(a.Variants) == 0 {
+		v.add("variants", "This collection should contain 1 element or more.")
+	}
+
+	seen := map[string]bool{}
+
+	for i, vr := range a.Variants {
+		path := fmt.Sprintf("variants[%d].", i)
+
+		v.variant(path, Variant{
+			ArticleCode:     vr.ArticleCode,
+			Name:            vr.Name,
+			Description:     vr.Description,
+			Ean:             vr.Ean,
+			Sku:             vr.Sku,
+			HsTariffCode:    vr.HsTariffCode,
+			Height:          int64(vr.Height),
+			Depth:           int64(vr.Depth),
+			Width:           int64(vr.Width),
+			Weight:          int64(vr.Weight),
+			CountryOfOrigin: vr.CountryOfOrigin,
+			Value:           vr.Value,
+		})
+
+		if vr.ArticleCode != "" && seen[vr.ArticleCode] {
+			v.add(path+"article_code", "This article code is already used by another variant.")
+		}
+
+		seen[vr.ArticleCode] = true
+	}
+
+	return v.err()
+}
+
+// Validate checks the variant before it is created and returns all
+// violations at once as a *BaseError.
+func (vr Variant) Validate() error {
+	var v violations
+
+	v.variant("", vr)
+
+	return v.err()
+}
+
+func (v *violations) variant(path string, vr Variant) {
+	v.required(path+"article_code", vr.ArticleCode)
+	v.required(path+"name", vr.Name)
+	v.maxLength(path+"article_code", vr.ArticleCode, maxReferenceLength)
+	v.maxLength(path+"name", vr.Name, maxTextLength)
+	v.maxLength(path+"description", vr.Description, maxTextLength)
+	v.maxLength(path+"ean", vr.Ean, maxReferenceLength)
+	v.maxLength(path+"sku", vr.Sku, maxReferenceLength)
+	v.maxLength(path+"hs_tariff_code", vr.HsTariffCode, maxReferenceLength)
+	v.country(path+"country_of_origin", vr.CountryOfOrigin)
+	v.notNegative(path+"height", vr.Height)
+	v.notNegative(path+"depth", vr.Depth)
+	v.notNegative(path+"width", vr.Width)
+	v.notNegative(path+"weight", vr.Weight)
+	v.amount(path+"value", vr.Value)
+}
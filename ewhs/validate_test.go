@@ -0,0 +1,167 @@
+package ewhs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func validOrder() Order {
+	return Order{
+		ExternalReference: "1644571933",
+		ShippingEmail:     "john.doe@example.com",
+		Currency:          "EUR",
+		ShippingAddress: ShippingAddress{
+			AddressedTo:  "eWarehousing Solutions",
+			Street:       "Nijverheidsweg",
+			StreetNumber: "27",
+			City:         "Heinenoord",
+			Zipcode:      "3331MB",
+			Country:      "NL",
+		},
+		OrderLines: []OrderLine{
+			{ArticleCode: "green_jacket", Quantity: 3, Price: "12.95"},
+		},
+	}
+}
+
+func violationPaths(t *testing.T, err error) []string {
+	t.Helper()
+
+	if err == nil {
+		return nil
+	}
+
+	var be *BaseError
+	if !errors.As(err, &be) {
+		t.Fatalf("expected a *BaseError, got %T", err)
+	}
+
+	assert.Equal(t, http.StatusUnprocessableEntity, be.Status)
+	assert.Equal(t, ValidationFailed, be.Title)
+
+	var paths []string
+	for _, v := range be.Violations {
+		paths = append(paths, v.PropertyPath)
+	}
+
+	return paths
+}
+
+func TestOrder_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(o *Order)
+		want   []string
+	}{
+		{"valid", func(o *Order) {}, nil},
+		{"missing external reference", func(o *Order) { o.ExternalReference = "" }, []string{"external_reference"}},
+		{"invalid email", func(o *Order) { o.ShippingEmail = "john.doe@" }, []string{"shipping_email"}},
+		{"email with a display name", func(o *Order) { o.ShippingEmail = "John <john@example.com>" }, []string{"shipping_email"}},
+		{"unknown country", func(o *Order) { o.ShippingAddress.Country = "XX" }, []string{"shipping_address.country"}},
+		{"lowercase country", func(o *Order) { o.ShippingAddress.Country = "nl" }, []string{"shipping_address.country"}},
+		{"missing zipcode", func(o *Order) { o.ShippingAddress.Zipcode = " " }, []string{"shipping_address.zipcode"}},
+		{"invalid currency", func(o *Order) { o.Currency = "euro" }, []string{"currency"}},
+		{"invalid delivery date", func(o *Order) { o.RequestedDeliveryDate = "15-10-2022" }, []string{"requested_delivery_date"}},
+		{"long note", func(o *Order) { o.Note = strings.Repeat("x", maxNoteLength+1) }, []string{"note"}},
+		{"no order lines", func(o *Order) { o.OrderLines = nil }, []string{"order_lines"}},
+		{"zero quantity", func(o *Order) { o.OrderLines[0].Quantity = 0 }, []string{"order_lines[0].quantity"}},
+		{"negative price", func(o *Order) { o.OrderLines[0].Price = "-1.00" }, []string{"order_lines[0].price"}},
+		{"invalid price", func(o *Order) { o.OrderLines[0].Price = "12,95" }, []string{"order_lines[0].price"}},
+		{
+			"duplicate article code",
+			func(o *Order) {
+				o.OrderLines = append(o.OrderLines, OrderLine{ArticleCode: "green_jacket", Quantity: 1})
+			},
+			[]string{"order_lines[1].article_code"},
+		},
+		{
+			"all violations at once",
+			func(o *Order) {
+				o.ExternalReference = ""
+				o.ShippingAddress = ShippingAddress{}
+				o.OrderLines[0].Quantity = -1
+			},
+			[]string{
+				"external_reference",
+				"shipping_address.addressed_to",
+				"shipping_address.street",
+				"shipping_address.city",
+				"shipping_address.zipcode",
+				"shipping_address.country",
+				"order_lines[0].quantity",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := validOrder()
+			tt.modify(&o)
+
+			assert.Equal(t, tt.want, violationPaths(t, o.Validate()))
+		})
+	}
+}
+
+func TestInbound_Validate(t *testing.T) {
+	inbound := Inbound{
+		ExternalReference: "PO-2022-0042",
+		InboundDate:       "2022-03-04",
+		InboundLines: []InboundLine{
+			{ArticleCode: "cream", Quantity: 10, LotNumber: "L1"},
+			{ArticleCode: "cream", Quantity: 5, LotNumber: "L2"},
+		},
+	}
+	assert.Nil(t, inbound.Validate())
+
+	inbound.InboundDate = ""
+	inbound.InboundLines = append(inbound.InboundLines, InboundLine{ArticleCode: "cream", Quantity: 0, LotNumber: "L2"})
+
+	assert.Equal(t, []string{
+		"inbound_date",
+		"inbound_lines[2].quantity",
+		"inbound_lines[2].article_code",
+	}, violationPaths(t, inbound.Validate()))
+}
+
+func TestArticle_Validate(t *testing.T) {
+	article := Article{
+		Name: "Jacket",
+		Variants: []ArticleVariant{
+			{ArticleCode: "green_jacket", Name: "Green jacket", CountryOfOrigin: "NL"},
+			{ArticleCode: "green_jacket", Name: "", CountryOfOrigin: "Netherlands", Weight: -1},
+		},
+	}
+
+	assert.Equal(t, []string{
+		"variants[1].name",
+		"variants[1].country_of_origin",
+		"variants[1].weight",
+		"variants[1].article_code",
+	}, violationPaths(t, article.Validate()))
+
+	assert.Equal(t, []string{"article_code", "name"}, violationPaths(t, Variant{}.Validate()))
+}
+
+func TestOrdersService_CreateViolations(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+
+	tMux.HandleFunc("/wms/orders/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"title": "An error occurred", "violations": [{"propertyPath": "shipping_address.zipcode", "message": "This value should not be blank."}]}`))
+	})
+
+	_, _, err := tClient.Orders.Create(context.Background(), validOrder())
+
+	var be *BaseError
+	assert.True(t, errors.As(err, &be))
+	assert.Equal(t, []Violation{{PropertyPath: "shipping_address.zipcode", Message: "This value should not be blank."}}, be.Violations)
+}
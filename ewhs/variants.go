@@ -9,28 +9,54 @@ import (
 type VariantsService service
 
 type Variant struct {
-	ID                 string  `json:"id,omitempty"`
-	ArticleCode        string  `json:"article_code,omitempty"`
-	Name               string  `json:"name,omitempty"`
-	Description        string  `json:"description,omitempty"`
-	Ean                string  `json:"ean,omitempty"`
-	Sku                string  `json:"sku,omitempty"`
-	HsTariffCode       string  `json:"hs_tariff_code,omitempty"`
-	Height             int64   `json:"height,omitempty"`
-	Depth              int64   `json:"depth,omitempty"`
-	Width              int64   `json:"width,omitempty"`
-	Weight             int64   `json:"weight,omitempty"`
-	Expirable          bool    `json:"expirable,omitempty"`
-	CountryOfOrigin    string  `json:"country_of_origin,omitempty"`
-	UsingSerialNumbers bool    `json:"using_serial_numbers,omitempty"`
-	Value              float64 `json:"value,omitempty"`
+	ID                 string     `json:"id,omitempty"`
+	ArticleCode        string     `json:"article_code,omitempty"`
+	Name               string     `json:"name,omitempty"`
+	Description        string     `json:"description,omitempty"`
+	Ean                string     `json:"ean,omitempty"`
+	Sku                string     `json:"sku,omitempty"`
+	HsTariffCode       string     `json:"hs_tariff_code,omitempty"`
+	Height             int64      `json:"height,omitempty"`
+	Depth              int64      `json:"depth,omitempty"`
+	Width              int64      `json:"width,omitempty"`
+	Weight             int64      `json:"weight,omitempty"`
+	Expirable          bool       `json:"expirable,omitempty"`
+	CountryOfOrigin    string     `json:"country_of_origin,omitempty"`
+	UsingSerialNumbers bool       `json:"using_serial_numbers,omitempty"`
+	Value              MajorUnits `json:"value,omitempty"`
+}
+
+// VariantUpdate holds the fields to change with VariantsService.Patch. Only
+// the fields that are set are sent, so for example Expirable can be set to
+// false.
+type VariantUpdate struct {
+	ArticleCode        Nullable[string]     `json:"article_code,omitempty"`
+	Name               Nullable[string]     `json:"name,omitempty"`
+	Description        Nullable[string]     `json:"description,omitempty"`
+	Ean                Nullable[string]     `json:"ean,omitempty"`
+	Sku                Nullable[string]     `json:"sku,omitempty"`
+	HsTariffCode       Nullable[string]     `json:"hs_tariff_code,omitempty"`
+	Height             Nullable[int64]      `json:"height,omitempty"`
+	Depth              Nullable[int64]      `json:"depth,omitempty"`
+	Width              Nullable[int64]      `json:"width,omitempty"`
+	Weight             Nullable[int64]      `json:"weight,omitempty"`
+	Expirable          Nullable[bool]       `json:"expirable,omitempty"`
+	CountryOfOrigin    Nullable[string]     `json:"country_of_origin,omitempty"`
+	UsingSerialNumbers Nullable[bool]       `json:"using_serial_numbers,omitempty"`
+	Value              Nullable[MajorUnits] `json:"value,omitempty"`
 }
 
 type VariantListOptions struct {
-	From      string `url:"from,omitempty"`
-	To        string `url:"to,omitempty"`
-	Limit     int    `url:"limit,omitempty"`
-	Direction string `url:"direction,omitempty"`
+	ArticleCode string `url:"article_code,omitempty"`
+	Ean         string `url:"ean,omitempty"`
+	Sku         string `url:"sku,omitempty"`
+	Search      string `url:"search,omitempty"`
+	ModifiedGte string `url:"modified_gte,omitempty"`
+	Page        int    `url:"page,omitempty"`
+	From        string `url:"from,omitempty"`
+	To          string `url:"to,omitempty"`
+	Limit       int    `url:"limit,omitempty"`
+	Direction   string `url:"direction,omitempty"`
 }
 
 func (vs *VariantsService) List(ctx context.Context, opts *VariantListOptions) (list *[]Variant, res *Response, err error) {
@@ -63,14 +89,14 @@ func (vs *VariantsService) Get(ctx context.Context, variantID string) (variant *
 	return
 }
 
-func (vs *VariantsService) Create(ctx context.Context, ord Order) (order *Order, res *Response, err error) {
-	res, err = vs.client.post(ctx, "wms/variants/", ord, nil)
+func (vs *VariantsService) Create(ctx context.Context, vr Variant) (variant *Variant, res *Response, err error) {
+	res, err = vs.client.post(ctx, "wms/variants/", vr, nil)
 
 	if err != nil {
 		return
 	}
 
-	if err = json.Unmarshal(res.content, &order); err != nil {
+	if err = json.Unmarshal(res.content, &variant); err != nil {
 		return
 	}
 
@@ -90,3 +116,60 @@ func (vs *VariantsService) Update(ctx context.Context, variantID string, vr Vari
 
 	return
 }
+
+// Patch changes the fields set in upd and leaves all other fields untouched.
+func (vs *VariantsService) Patch(ctx context.Context, variantID string, upd VariantUpdate) (variant *Variant, res *Response, err error) {
+	res, err = vs.client.patch(ctx, fmt.Sprintf("wms/variants/%s/", variantID), upd, nil)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &variant); err != nil {
+		return
+	}
+
+	return
+}
+
+// Delete archives a variant, it is no longer available for new orders.
+func (vs *VariantsService) Delete(ctx context.Context, variantID string) (res *Response, err error) {
+	res, err = vs.client.delete(ctx, fmt.Sprintf("wms/variants/%s/", variantID), nil)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// FindByEan returns the variant with the given EAN, or nil when there is none.
+func (vs *VariantsService) FindByEan(ctx context.Context, ean string) (variant *Variant, res *Response, err error) {
+	return vs.find(ctx, &VariantListOptions{Ean: ean}, func(v Variant) bool { return v.Ean == ean })
+}
+
+// FindBySku returns the variant with the given SKU, or nil when there is none.
+func (vs *VariantsService) FindBySku(ctx context.Context, sku string) (variant *Variant, res *Response, err error) {
+	return vs.find(ctx, &VariantListOptions{Sku: sku}, func(v Variant) bool { return v.Sku == sku })
+}
+
+// FindByArticleCode returns the variant with the given article code, or nil
+// when there is none.
+func (vs *VariantsService) FindByArticleCode(ctx context.Context, articleCode string) (variant *Variant, res *Response, err error) {
+	return vs.find(ctx, &VariantListOptions{ArticleCode: articleCode}, func(v Variant) bool { return v.ArticleCode == articleCode })
+}
+
+// find lists the variants matching opts and returns the first one for which
+// match is true, as the API filters may also return partial matches.
+func (vs *VariantsService) find(ctx context.Context, opts *VariantListOptions, match func(Variant) bool) (variant *Variant, res *Response, err error) {
+	list, res, err := vs.List(ctx, opts)
+	if err != nil || list == nil {
+		return
+	}
+
+	for i := range *list {
+		if match((*list)[i]) {
+			return &(*list)[i], res, nil
+		}
+	}
+
+	return
+}
@@ -0,0 +1,129 @@
+package ewhs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/ewarehousing-solutions/ewhs-api-go/test/testdata"
+	"github.com/stretchr/testify/suite"
+)
+
+type variantsServiceSuite struct{ suite.Suite }
+
+func (vs *variantsServiceSuite) TestVariantsService_Create() {
+	type args struct {
+		ctx     context.Context
+		variant Variant
+	}
+	cases := []struct {
+		name    string
+		args    args
+		wantErr bool
+		err     error
+		pre     func()
+		handler http.HandlerFunc
+	}{
+		{
+			"create variants works as expected.",
+			args{
+				context.Background(),
+				Variant{
+					ArticleCode: "default_variant_b_id",
+					Ean:         "8712345678906",
+				},
+			},
+			false,
+			nil,
+			func() {
+				tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+			},
+			func(w http.ResponseWriter, r *http.Request) {
+				testHeader(vs.T(), r, AuthHeader, "Bearer eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+				testMethod(vs.T(), r, "POST")
+
+				_, _ = w.Write([]byte(testdata.GetVariantResponse))
+			},
+		},
+		{
+			"create variants, an error is returned from the server",
+			args{
+				context.Background(),
+				Variant{},
+			},
+			true,
+			fmt.Errorf("500 - 500 Internal Server Error"),
+			func() {
+				tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+			},
+			errorHandler,
+		},
+	}
+
+	for _, c := range cases {
+		setup()
+		defer teardown()
+
+		vs.T().Run(c.name, func(t *testing.T) {
+			c.pre()
+			tMux.HandleFunc("/wms/variants/", c.handler)
+
+			v, res, err := tClient.Variants.Create(c.args.ctx, c.args.variant)
+			if c.wantErr {
+				vs.NotNil(err)
+				vs.EqualError(err, c.err.Error())
+			} else {
+				vs.Nil(err)
+				vs.IsType(&Variant{}, v)
+				vs.Equal("8712345678906", v.Ean)
+				vs.IsType(&http.Response{}, res.Response)
+			}
+		})
+	}
+}
+
+func (vs *variantsServiceSuite) TestVariantsService_FindByEan() {
+	cases := []struct {
+		name   string
+		ean    string
+		wantID string
+	}{
+		{
+			"an exact match is returned.",
+			"8712345678906",
+			"87557e7a-4f4d-44eb-bbf1-c9d83df90099",
+		},
+		{
+			"a partial match is ignored.",
+			"8712345",
+			"",
+		},
+	}
+
+	for _, c := range cases {
+		setup()
+		defer teardown()
+
+		vs.T().Run(c.name, func(t *testing.T) {
+			tClient.WithAuthToken("eyJ0eXAiOiJKV1QiLCJhbGciOiJSUzI1NiJ9")
+			tMux.HandleFunc("/wms/variants/", func(w http.ResponseWriter, r *http.Request) {
+				testQuery(t, r, "ean="+c.ean)
+				_, _ = w.Write([]byte(testdata.ListVariantsResponse))
+			})
+
+			v, _, err := tClient.Variants.FindByEan(context.Background(), c.ean)
+			vs.Nil(err)
+
+			if c.wantID == "" {
+				vs.Nil(v)
+			} else {
+				vs.Equal(c.wantID, v.ID)
+			}
+		})
+	}
+}
+
+func TestVariantsService(t *testing.T) {
+	suite.Run(t, new(variantsServiceSuite))
+}
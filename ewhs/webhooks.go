@@ -9,10 +9,10 @@ import (
 type WebhooksService service
 
 type WebhookResults struct {
-	Count    int         `json:"count,omitempty"`
-	Next     interface{} `json:"next,omitempty"`
-	Previous interface{} `json:"previous,omitempty"`
-	Results  []Webhook   `json:"results,omitempty"`
+	Count    int              `json:"count,omitempty"`
+	Next     Nullable[string] `json:"next,omitempty"`
+	Previous Nullable[string] `json:"previous,omitempty"`
+	Results  []Webhook        `json:"results,omitempty"`
 }
 
 type Webhook struct {
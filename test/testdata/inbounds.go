@@ -0,0 +1,51 @@
+package testdata
+
+const GetInboundResponse = `{
+  "id": "4f6e4a3c-6a0e-4bd9-9b8e-2f5f0f5b8a10",
+  "created_at": "2022-03-01T10:15:00+00:00",
+  "reference": "INB00000000042",
+  "external_reference": "PO-2022-0042",
+  "status": "partially_received",
+  "type": 1,
+  "inbound_date": "2022-03-04",
+  "note": "Two pallets",
+  "supplier": {
+    "id": "0e543c99-f371-42c8-8389-e335453fdd10",
+    "code": "ACME",
+    "name": "Acme Supplies"
+  },
+  "inbound_lines": [
+    {
+      "id": "9d0b8a1e-1f0c-4d6e-8f51-0a1f5c9d2e01",
+      "article_code": "default_variant_a_id",
+      "quantity": 10,
+      "received_quantity": 8,
+      "lot_number": null,
+      "expiry_date": null,
+      "variant": {
+        "id": "1e19da60-4d2b-4c15-8f4e-8978f6113c00",
+        "article_code": "default_variant_a_id",
+        "ean": "default_variant_a_id"
+      }
+    },
+    {
+      "id": "9d0b8a1e-1f0c-4d6e-8f51-0a1f5c9d2e02",
+      "article_code": "default_variant_b_id",
+      "quantity": 5,
+      "received_quantity": 6,
+      "lot_number": "L2022-03",
+      "expiry_date": "2023-03-01",
+      "variant": {
+        "id": "87557e7a-4f4d-44eb-bbf1-c9d83df90099",
+        "article_code": "default_variant_b_id",
+        "ean": "default_variant_b_id"
+      }
+    },
+    {
+      "id": "9d0b8a1e-1f0c-4d6e-8f51-0a1f5c9d2e03",
+      "article_code": "unannounced_variant",
+      "quantity": 0,
+      "received_quantity": 3
+    }
+  ]
+}`
@@ -0,0 +1,46 @@
+package testdata
+
+const GetModificationResponse = `{
+  "id": "5d4b4ba3-0d0c-4b8e-8d36-1c4f2d1c2a61",
+  "reference": "MOD0000001",
+  "external_reference": "count-2022-10",
+  "status": "created",
+  "reason": "stock_count",
+  "note": "Yearly stock count",
+  "created_at": "2022-10-14T09:12:44+00:00",
+  "approved_at": null,
+  "modification_lines": [
+    {
+      "article_code": "default_variant_a_id",
+      "quantity": -2,
+      "reason": "damaged",
+      "note": null
+    },
+    {
+      "article_code": "default_variant_b_id",
+      "quantity": 5,
+      "reason": "found",
+      "note": null
+    }
+  ]
+}`
+
+const ListModificationsResponse = `[
+  {
+    "id": "5d4b4ba3-0d0c-4b8e-8d36-1c4f2d1c2a61",
+    "reference": "MOD0000001",
+    "external_reference": "count-2022-10",
+    "status": "created",
+    "reason": "stock_count",
+    "created_at": "2022-10-14T09:12:44+00:00"
+  },
+  {
+    "id": "b2a7e0f4-9f1e-4c55-a1b8-7d8e3c4f5a62",
+    "reference": "MOD0000002",
+    "external_reference": "damaged-2022-10",
+    "status": "approved",
+    "reason": "damaged",
+    "created_at": "2022-10-15T11:02:10+00:00",
+    "approved_at": "2022-10-15T13:30:00+00:00"
+  }
+]`
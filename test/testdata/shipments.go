@@ -0,0 +1,58 @@
+package testdata
+
+const ListShipmentsResponse = `[
+  {
+    "id": "5a4e1c1c-2f0a-4f4e-9d44-6a3c4a1f0b02",
+    "created_at": "2022-02-12T14:00:00+00:00",
+    "order_id": "c9165f93-8301-4aaa-9f64-27f191c0c778",
+    "order_external_reference": "1644571933",
+    "shipment_external_reference": null,
+    "reference": "SHP00000000002",
+    "shipment_labels": [
+      {
+        "label_code": "3SABCD0000002",
+        "tracking_code": "3SABCD0000002",
+        "tracking_url": "https://tracking.example.com/3SABCD0000002"
+      }
+    ],
+    "shipment_lines": [
+      {
+        "shipped_quantity": 5,
+        "shipped_ean": "default_variant_a_id",
+        "shipped_article_code": "default_variant_a_id",
+        "shipped_sku": "default_variant_a_id",
+        "serial_numbers": []
+      }
+    ]
+  },
+  {
+    "id": "5a4e1c1c-2f0a-4f4e-9d44-6a3c4a1f0b01",
+    "created_at": "2022-02-11T10:00:00+00:00",
+    "order_id": "c9165f93-8301-4aaa-9f64-27f191c0c778",
+    "order_external_reference": "1644571933",
+    "shipment_external_reference": null,
+    "reference": "SHP00000000001",
+    "shipment_labels": [
+      {
+        "label_code": "3SABCD0000001",
+        "tracking_code": "3SABCD0000001",
+        "tracking_url": "https://tracking.example.com/3SABCD0000001"
+      }
+    ],
+    "shipment_lines": [
+      {
+        "shipped_quantity": 7,
+        "variant": {
+          "id": "87557e7a-4f4d-44eb-bbf1-c9d83df90099",
+          "article_code": "default_variant_b_id"
+        },
+        "serial_numbers": []
+      },
+      {
+        "shipped_quantity": 8,
+        "shipped_article_code": "default_variant_a_id",
+        "serial_numbers": []
+      }
+    ]
+  }
+]`
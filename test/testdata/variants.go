@@ -0,0 +1,42 @@
+package testdata
+
+const GetVariantResponse = `{
+  "id": "87557e7a-4f4d-44eb-bbf1-c9d83df90099",
+  "article_code": "default_variant_b_id",
+  "name": "default_variant_b_id",
+  "description": null,
+  "ean": "8712345678906",
+  "sku": "default_variant_b_id",
+  "hs_tariff_code": null,
+  "height": null,
+  "depth": null,
+  "width": null,
+  "weight": null,
+  "expirable": false,
+  "country_of_origin": null,
+  "using_serial_numbers": false,
+  "value": 0
+}`
+
+const ListVariantsResponse = `[
+  {
+    "id": "1e19da60-4d2b-4c15-8f4e-8978f6113c00",
+    "article_code": "default_variant_a_id",
+    "name": "default_variant_a_id",
+    "ean": "87123456789",
+    "sku": "default_variant_a_id",
+    "expirable": false,
+    "using_serial_numbers": false,
+    "value": 0
+  },
+  {
+    "id": "87557e7a-4f4d-44eb-bbf1-c9d83df90099",
+    "article_code": "default_variant_b_id",
+    "name": "default_variant_b_id",
+    "ean": "8712345678906",
+    "sku": "default_variant_b_id",
+    "expirable": false,
+    "using_serial_numbers": false,
+    "value": 0
+  }
+]`